@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/memory"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/contract"
+)
+
+// MemoryDatabase wraps *memory.Store to implement contract.Database interface
+type MemoryDatabase struct {
+	store *memory.Store
+}
+
+// NewMemoryDatabase creates a new in-process database adapter
+func NewMemoryDatabase(store *memory.Store) contract.Database {
+	return &MemoryDatabase{
+		store: store,
+	}
+}
+
+func (m *MemoryDatabase) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *MemoryDatabase) Close(ctx context.Context) error {
+	return nil
+}
+
+func (m *MemoryDatabase) GetNative() interface{} {
+	return m.store
+}
+
+// MemoryDatabaseFactory implements contract.DatabaseFactory
+type MemoryDatabaseFactory struct{}
+
+// NewMemoryDatabaseFactory creates a new in-process database factory
+func NewMemoryDatabaseFactory() contract.DatabaseFactory {
+	return &MemoryDatabaseFactory{}
+}
+
+// CreateDatabase creates an empty store, seeded from the configured fixtures
+// file if there is one.
+func (f *MemoryDatabaseFactory) CreateDatabase(cfg contract.Config, logger contract.Logger) (contract.Database, error) {
+	store := memory.NewStore()
+	if path := cfg.GetStorageFixtures(); path != "" {
+		fixtures, err := memory.LoadFixtures(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := store.Load(fixtures); err != nil {
+			return nil, err
+		}
+		logger.Info("Loaded storage fixtures", "path", path)
+	}
+	logger.Warn("Using in-memory storage; data is lost on restart")
+	return NewMemoryDatabase(store), nil
+}
+
+func (f *MemoryDatabaseFactory) CloseDatabase(db contract.Database) error {
+	return db.Close(context.Background())
+}
+
+// MemoryCache implements contract.Cache for STORAGE=memory, where the
+// memory store also holds the data Redis would. It has no native client.
+type MemoryCache struct{}
+
+// NewMemoryCache creates a new no-op cache adapter
+func NewMemoryCache() contract.Cache {
+	return &MemoryCache{}
+}
+
+func (m *MemoryCache) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *MemoryCache) Close() error {
+	return nil
+}
+
+func (m *MemoryCache) GetNative() interface{} {
+	return nil
+}
+
+// MemoryCacheFactory implements contract.CacheFactory
+type MemoryCacheFactory struct{}
+
+// NewMemoryCacheFactory creates a new no-op cache factory
+func NewMemoryCacheFactory() contract.CacheFactory {
+	return &MemoryCacheFactory{}
+}
+
+func (f *MemoryCacheFactory) CreateCache(cfg contract.Config, logger contract.Logger) (contract.Cache, error) {
+	return NewMemoryCache(), nil
+}
+
+func (f *MemoryCacheFactory) CloseCache(cache contract.Cache) error {
+	return cache.Close()
+}
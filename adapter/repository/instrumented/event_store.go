@@ -0,0 +1,28 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+type eventStore struct {
+	probe
+	next port.EventStore
+}
+
+// NewEventStore wraps next with call metrics.
+func NewEventStore(next port.EventStore, backend string) port.EventStore {
+	return &eventStore{probe: probe{backend: backend, repo: "EventStore"}, next: next}
+}
+
+func (s *eventStore) Append(ctx context.Context, roomID string, events ...*entity.GameEvent) (err error) {
+	defer s.observe(&ctx, "Append")(&err)
+	return s.next.Append(ctx, roomID, events...)
+}
+
+func (s *eventStore) List(ctx context.Context, roomID string, fromSeq, toSeq int64) (_ []*entity.GameEvent, err error) {
+	defer s.observe(&ctx, "List")(&err)
+	return s.next.List(ctx, roomID, fromSeq, toSeq)
+}
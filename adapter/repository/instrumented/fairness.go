@@ -0,0 +1,53 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+type fairSessionRepository struct {
+	probe
+	next port.FairSessionRepository
+}
+
+// NewFairSessionRepository wraps next with call metrics.
+func NewFairSessionRepository(next port.FairSessionRepository, backend string) port.FairSessionRepository {
+	return &fairSessionRepository{probe: probe{backend: backend, repo: "FairSessionRepository"}, next: next}
+}
+
+func (r *fairSessionRepository) Save(ctx context.Context, session *entity.FairSession) (err error) {
+	defer r.observe(&ctx, "Save")(&err)
+	return r.next.Save(ctx, session)
+}
+
+func (r *fairSessionRepository) GetByID(ctx context.Context, sessionID string) (_ *entity.FairSession, err error) {
+	defer r.observe(&ctx, "GetByID")(&err)
+	return r.next.GetByID(ctx, sessionID)
+}
+
+func (r *fairSessionRepository) GetActive(ctx context.Context, roomID, playerID string) (_ *entity.FairSession, err error) {
+	defer r.observe(&ctx, "GetActive")(&err)
+	return r.next.GetActive(ctx, roomID, playerID)
+}
+
+type fairShotRepository struct {
+	probe
+	next port.FairShotRepository
+}
+
+// NewFairShotRepository wraps next with call metrics.
+func NewFairShotRepository(next port.FairShotRepository, backend string) port.FairShotRepository {
+	return &fairShotRepository{probe: probe{backend: backend, repo: "FairShotRepository"}, next: next}
+}
+
+func (r *fairShotRepository) Save(ctx context.Context, shot *entity.FairShot) (err error) {
+	defer r.observe(&ctx, "Save")(&err)
+	return r.next.Save(ctx, shot)
+}
+
+func (r *fairShotRepository) Get(ctx context.Context, sessionID string, nonce uint64) (_ *entity.FairShot, err error) {
+	defer r.observe(&ctx, "Get")(&err)
+	return r.next.Get(ctx, sessionID, nonce)
+}
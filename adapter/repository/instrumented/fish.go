@@ -0,0 +1,23 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+type fishRepository struct {
+	probe
+	next port.FishRepository
+}
+
+// NewFishRepository wraps next with call metrics.
+func NewFishRepository(next port.FishRepository, backend string) port.FishRepository {
+	return &fishRepository{probe: probe{backend: backend, repo: "FishRepository"}, next: next}
+}
+
+func (r *fishRepository) GetTypeByID(ctx context.Context, fishID int) (_ *entity.FishType, err error) {
+	defer r.observe(&ctx, "GetTypeByID")(&err)
+	return r.next.GetTypeByID(ctx, fishID)
+}
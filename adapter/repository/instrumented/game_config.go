@@ -0,0 +1,114 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+type gameConfigRepository struct {
+	probe
+	next port.GameConfigRepository
+}
+
+// NewGameConfigRepository wraps next with call metrics.
+func NewGameConfigRepository(next port.GameConfigRepository, backend string) port.GameConfigRepository {
+	return &gameConfigRepository{probe: probe{backend: backend, repo: "GameConfigRepository"}, next: next}
+}
+
+func (r *gameConfigRepository) GetBulletConfig(ctx context.Context, gameName string) (_ *gameBaseModels.BulletConfig, err error) {
+	defer r.observe(&ctx, "GetBulletConfig")(&err)
+	return r.next.GetBulletConfig(ctx, gameName)
+}
+
+func (r *gameConfigRepository) GetGameConfig(ctx context.Context, gameName string) (_ *gameBaseModels.GameConfig, err error) {
+	defer r.observe(&ctx, "GetGameConfig")(&err)
+	return r.next.GetGameConfig(ctx, gameName)
+}
+
+func (r *gameConfigRepository) GetGameFeatures(ctx context.Context, gameName string) (_ *gameBaseModels.GameFeatures, err error) {
+	defer r.observe(&ctx, "GetGameFeatures")(&err)
+	return r.next.GetGameFeatures(ctx, gameName)
+}
+
+func (r *gameConfigRepository) GetGamePaths(ctx context.Context, gameName string) (_ *gameBaseModels.GamePaths, err error) {
+	defer r.observe(&ctx, "GetGamePaths")(&err)
+	return r.next.GetGamePaths(ctx, gameName)
+}
+
+func (r *gameConfigRepository) GetGameRTP(ctx context.Context, gameName string) (_ *gameBaseModels.GameRTP, err error) {
+	defer r.observe(&ctx, "GetGameRTP")(&err)
+	return r.next.GetGameRTP(ctx, gameName)
+}
+
+func (r *gameConfigRepository) GetGameFishTypes(ctx context.Context, gameName string) (_ *gameBaseModels.GameFishTypes, err error) {
+	defer r.observe(&ctx, "GetGameFishTypes")(&err)
+	return r.next.GetGameFishTypes(ctx, gameName)
+}
+
+type gameConfigVersionStore struct {
+	probe
+	next port.GameConfigVersionStore
+}
+
+// NewGameConfigVersionStore wraps next with call metrics.
+func NewGameConfigVersionStore(next port.GameConfigVersionStore, backend string) port.GameConfigVersionStore {
+	return &gameConfigVersionStore{probe: probe{backend: backend, repo: "GameConfigVersionStore"}, next: next}
+}
+
+func (r *gameConfigVersionStore) SaveVersion(ctx context.Context, version *gameBaseModels.ConfigVersion) (err error) {
+	defer r.observe(&ctx, "SaveVersion")(&err)
+	return r.next.SaveVersion(ctx, version)
+}
+
+func (r *gameConfigVersionStore) GetVersion(ctx context.Context, kind, gameName string, version int64) (_ *gameBaseModels.ConfigVersion, err error) {
+	defer r.observe(&ctx, "GetVersion")(&err)
+	return r.next.GetVersion(ctx, kind, gameName, version)
+}
+
+func (r *gameConfigVersionStore) ListVersions(ctx context.Context, kind, gameName string, limit int) (_ []*gameBaseModels.ConfigVersion, err error) {
+	defer r.observe(&ctx, "ListVersions")(&err)
+	return r.next.ListVersions(ctx, kind, gameName, limit)
+}
+
+func (r *gameConfigVersionStore) GetRollout(ctx context.Context, kind, gameName string) (_ *gameBaseModels.ConfigRollout, err error) {
+	defer r.observe(&ctx, "GetRollout")(&err)
+	return r.next.GetRollout(ctx, kind, gameName)
+}
+
+func (r *gameConfigVersionStore) SaveRollout(ctx context.Context, rollout *gameBaseModels.ConfigRollout) (err error) {
+	defer r.observe(&ctx, "SaveRollout")(&err)
+	return r.next.SaveRollout(ctx, rollout)
+}
+
+type gameConfigStore struct {
+	port.GameConfigRepository
+	probe
+	next port.GameConfigStore
+}
+
+// NewGameConfigStore wraps next with call metrics. The document getters
+// are reported under GameConfigRepository.
+func NewGameConfigStore(next port.GameConfigStore, backend string) port.GameConfigStore {
+	return &gameConfigStore{
+		GameConfigRepository: NewGameConfigRepository(next, backend),
+		probe:                probe{backend: backend, repo: "GameConfigStore"},
+		next:                 next,
+	}
+}
+
+func (r *gameConfigStore) GetDocument(ctx context.Context, kind, gameName string) (_ gameBaseModels.ConfigDocument, err error) {
+	defer r.observe(&ctx, "GetDocument")(&err)
+	return r.next.GetDocument(ctx, kind, gameName)
+}
+
+func (r *gameConfigStore) SaveDocument(ctx context.Context, doc gameBaseModels.ConfigDocument, prevVersion int64) (err error) {
+	defer r.observe(&ctx, "SaveDocument")(&err)
+	return r.next.SaveDocument(ctx, doc, prevVersion)
+}
+
+func (r *gameConfigStore) ListGameNames(ctx context.Context) (_ []string, err error) {
+	defer r.observe(&ctx, "ListGameNames")(&err)
+	return r.next.ListGameNames(ctx)
+}
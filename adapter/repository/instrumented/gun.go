@@ -0,0 +1,23 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+type gunRepository struct {
+	probe
+	next port.GunRepository
+}
+
+// NewGunRepository wraps next with call metrics.
+func NewGunRepository(next port.GunRepository, backend string) port.GunRepository {
+	return &gunRepository{probe: probe{backend: backend, repo: "GunRepository"}, next: next}
+}
+
+func (r *gunRepository) GetByID(ctx context.Context, gunID int) (_ *entity.Gun, err error) {
+	defer r.observe(&ctx, "GetByID")(&err)
+	return r.next.GetByID(ctx, gunID)
+}
@@ -0,0 +1,28 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+type playerRepository struct {
+	probe
+	next port.PlayerRepository
+}
+
+// NewPlayerRepository wraps next with call metrics.
+func NewPlayerRepository(next port.PlayerRepository, backend string) port.PlayerRepository {
+	return &playerRepository{probe: probe{backend: backend, repo: "PlayerRepository"}, next: next}
+}
+
+func (r *playerRepository) GetByID(ctx context.Context, playerID string) (_ *entity.Player, err error) {
+	defer r.observe(&ctx, "GetByID")(&err)
+	return r.next.GetByID(ctx, playerID)
+}
+
+func (r *playerRepository) Save(ctx context.Context, player *entity.Player) (err error) {
+	defer r.observe(&ctx, "Save")(&err)
+	return r.next.Save(ctx, player)
+}
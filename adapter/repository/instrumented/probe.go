@@ -0,0 +1,57 @@
+// Package instrumented wraps repository ports with latency metrics and
+// trace spans, so every backend is measured the same way without touching
+// the adapters.
+package instrumented
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/metrics"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/BT2701/backend-fishing-gameplay/adapter/repository")
+
+// probe times the calls of one repository. backend names the store behind
+// it, e.g. "mongo" or "redis".
+type probe struct {
+	backend string
+	repo    string
+}
+
+// observe starts timing a call and a client span that replaces *ctx, so the
+// call and anything it does are traced under it. The returned func ends
+// both with the outcome taken from *errp:
+//
+//	defer r.observe(&ctx, "GetByID")(&err)
+func (p probe) observe(ctx *context.Context, method string) func(errp *error) {
+	start := time.Now()
+	name := p.repo + "." + method
+	var span trace.Span
+	*ctx, span = tracer.Start(*ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("repository.backend", p.backend)),
+	)
+	return func(errp *error) {
+		outcome := "ok"
+		if err := *errp; err != nil {
+			outcome = "error"
+			if errors.Is(err, apperr.ErrNotFound) {
+				// A miss is an answer, not a failure of the store.
+				outcome = "not_found"
+			} else {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+		}
+		span.SetAttributes(attribute.String("repository.outcome", outcome))
+		span.End()
+		metrics.RepositoryCallDuration.WithLabelValues(p.backend, name, outcome).Observe(time.Since(start).Seconds())
+	}
+}
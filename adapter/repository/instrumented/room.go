@@ -0,0 +1,33 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+type roomRepository struct {
+	probe
+	next port.RoomRepository
+}
+
+// NewRoomRepository wraps next with call metrics.
+func NewRoomRepository(next port.RoomRepository, backend string) port.RoomRepository {
+	return &roomRepository{probe: probe{backend: backend, repo: "RoomRepository"}, next: next}
+}
+
+func (r *roomRepository) GetByID(ctx context.Context, roomID string) (_ *entity.Room, err error) {
+	defer r.observe(&ctx, "GetByID")(&err)
+	return r.next.GetByID(ctx, roomID)
+}
+
+func (r *roomRepository) Save(ctx context.Context, room *entity.Room) (err error) {
+	defer r.observe(&ctx, "Save")(&err)
+	return r.next.Save(ctx, room)
+}
+
+func (r *roomRepository) ListWithExpiredBullets(ctx context.Context, nowMs int64, limit int) (_ []string, err error) {
+	defer r.observe(&ctx, "ListWithExpiredBullets")(&err)
+	return r.next.ListWithExpiredBullets(ctx, nowMs, limit)
+}
@@ -0,0 +1,34 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/metrics"
+)
+
+type rtpRepository struct {
+	probe
+	next port.RTPRepository
+}
+
+// NewRTPRepository wraps next with call metrics and publishes every saved
+// state as the room's live RTP.
+func NewRTPRepository(next port.RTPRepository, backend string) port.RTPRepository {
+	return &rtpRepository{probe: probe{backend: backend, repo: "RTPRepository"}, next: next}
+}
+
+func (r *rtpRepository) GetByRoomID(ctx context.Context, roomID string) (_ *entity.RTPState, err error) {
+	defer r.observe(&ctx, "GetByRoomID")(&err)
+	return r.next.GetByRoomID(ctx, roomID)
+}
+
+func (r *rtpRepository) Save(ctx context.Context, roomID string, state *entity.RTPState) (err error) {
+	defer r.observe(&ctx, "Save")(&err)
+	if err := r.next.Save(ctx, roomID, state); err != nil {
+		return err
+	}
+	metrics.SetRoomRTP(roomID, state.TotalBet, state.TotalWin)
+	return nil
+}
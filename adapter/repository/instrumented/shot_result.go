@@ -0,0 +1,34 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+type shotResultRepository struct {
+	probe
+	next port.ShotResultRepository
+}
+
+// NewShotResultRepository wraps next with call metrics.
+func NewShotResultRepository(next port.ShotResultRepository, backend string) port.ShotResultRepository {
+	return &shotResultRepository{probe: probe{backend: backend, repo: "ShotResultRepository"}, next: next}
+}
+
+func (r *shotResultRepository) Claim(ctx context.Context, playerID, bulletID string, ttl time.Duration) (_ bool, _ *entity.ShotResult, err error) {
+	defer r.observe(&ctx, "Claim")(&err)
+	return r.next.Claim(ctx, playerID, bulletID, ttl)
+}
+
+func (r *shotResultRepository) Complete(ctx context.Context, playerID, bulletID string, result *entity.ShotResult, ttl time.Duration) (err error) {
+	defer r.observe(&ctx, "Complete")(&err)
+	return r.next.Complete(ctx, playerID, bulletID, result, ttl)
+}
+
+func (r *shotResultRepository) Release(ctx context.Context, playerID, bulletID string) (err error) {
+	defer r.observe(&ctx, "Release")(&err)
+	return r.next.Release(ctx, playerID, bulletID)
+}
@@ -0,0 +1,28 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+type walletTransferRepository struct {
+	probe
+	next port.WalletTransferRepository
+}
+
+// NewWalletTransferRepository wraps next with call metrics.
+func NewWalletTransferRepository(next port.WalletTransferRepository, backend string) port.WalletTransferRepository {
+	return &walletTransferRepository{probe: probe{backend: backend, repo: "WalletTransferRepository"}, next: next}
+}
+
+func (r *walletTransferRepository) Save(ctx context.Context, transfer *entity.WalletTransfer) (err error) {
+	defer r.observe(&ctx, "Save")(&err)
+	return r.next.Save(ctx, transfer)
+}
+
+func (r *walletTransferRepository) ListPending(ctx context.Context, updatedBefore int64, limit int) (_ []*entity.WalletTransfer, err error) {
+	defer r.observe(&ctx, "ListPending")(&err)
+	return r.next.ListPending(ctx, updatedBefore, limit)
+}
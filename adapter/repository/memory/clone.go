@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// cloneBSON copies v through its BSON encoding, so stored values behave like
+// documents read back from MongoDB: callers never share memory with the
+// store and fields MongoDB would not persist are dropped.
+func cloneBSON[T any](v *T) (*T, error) {
+	raw, err := bson.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out T
+	if err := bson.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// cloneJSON copies v through its JSON encoding, matching the values the
+// Redis repositories store.
+func cloneJSON[T any](v *T) (*T, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out T
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port/porttest"
+)
+
+func TestRoomRepositoryContract(t *testing.T) {
+	porttest.RoomRepository(t, func(*testing.T) port.RoomRepository { return NewRoomRepository() })
+}
+
+func TestPlayerRepositoryContract(t *testing.T) {
+	porttest.PlayerRepository(t, func(*testing.T) port.PlayerRepository { return NewPlayerRepository() })
+}
+
+func TestFishRepositoryContract(t *testing.T) {
+	porttest.FishRepository(t, func(_ *testing.T, seed []*entity.FishType) port.FishRepository {
+		repo := NewFishRepository()
+		for _, fishType := range seed {
+			repo.SaveType(fishType)
+		}
+		return repo
+	})
+}
+
+func TestGunRepositoryContract(t *testing.T) {
+	porttest.GunRepository(t, func(_ *testing.T, seed []*entity.Gun) port.GunRepository {
+		repo := NewGunRepository()
+		for _, gun := range seed {
+			repo.Save(gun)
+		}
+		return repo
+	})
+}
+
+func TestRTPRepositoryContract(t *testing.T) {
+	porttest.RTPRepository(t, func(*testing.T) port.RTPRepository { return NewRTPRepository() })
+}
+
+func TestShotResultRepositoryContract(t *testing.T) {
+	porttest.ShotResultRepository(t, func(*testing.T) port.ShotResultRepository { return NewShotResultRepository() })
+}
+
+func TestGameConfigRepositoryContract(t *testing.T) {
+	porttest.GameConfigRepository(t, func(t *testing.T, seed []gameBaseModels.ConfigDocument) port.GameConfigRepository {
+		repo := NewGameConfigRepository()
+		for _, doc := range seed {
+			if err := repo.put(doc); err != nil {
+				t.Fatalf("seed %s: %v", doc.Kind(), err)
+			}
+		}
+		return repo
+	})
+}
+
+func TestGameConfigStoreContract(t *testing.T) {
+	porttest.GameConfigStore(t, func(*testing.T) port.GameConfigStore { return NewGameConfigRepository() })
+}
+
+func TestGameConfigVersionStoreContract(t *testing.T) {
+	porttest.GameConfigVersionStore(t, func(*testing.T) port.GameConfigVersionStore { return NewGameConfigVersionRepository() })
+}
+
+func TestWalletTransferRepositoryContract(t *testing.T) {
+	porttest.WalletTransferRepository(t, func(*testing.T) port.WalletTransferRepository { return NewWalletTransferRepository() })
+}
+
+func TestFairSessionRepositoryContract(t *testing.T) {
+	porttest.FairSessionRepository(t, func(*testing.T) port.FairSessionRepository { return NewFairSessionRepository() })
+}
+
+func TestFairShotRepositoryContract(t *testing.T) {
+	porttest.FairShotRepository(t, func(*testing.T) port.FairShotRepository { return NewFairShotRepository() })
+}
+
+func TestEventStoreContract(t *testing.T) {
+	porttest.EventStore(t, func(*testing.T) port.EventStore { return NewEventStore() })
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+type EventStore struct {
+	mu     sync.RWMutex
+	events map[string][]*entity.GameEvent
+}
+
+func NewEventStore() *EventStore {
+	return &EventStore{
+		events: map[string][]*entity.GameEvent{},
+	}
+}
+
+// Append numbers the events after the room's last stored event.
+func (s *EventStore) Append(ctx context.Context, roomID string, events ...*entity.GameEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := int64(len(s.events[roomID])) + 1
+	stored := make([]*entity.GameEvent, len(events))
+	for i, e := range events {
+		e.RoomID = roomID
+		e.Seq = next + int64(i)
+		copied, err := cloneBSON(e)
+		if err != nil {
+			return err
+		}
+		stored[i] = copied
+	}
+	s.events[roomID] = append(s.events[roomID], stored...)
+	return nil
+}
+
+func (s *EventStore) List(ctx context.Context, roomID string, fromSeq, toSeq int64) ([]*entity.GameEvent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	events := []*entity.GameEvent{}
+	for _, e := range s.events[roomID] {
+		if e.Seq < fromSeq || (toSeq > 0 && e.Seq > toSeq) {
+			continue
+		}
+		out, err := cloneBSON(e)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, out)
+	}
+	return events, nil
+}
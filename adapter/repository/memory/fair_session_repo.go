@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type FairSessionRepository struct {
+	mu       sync.Mutex
+	sessions map[string]*entity.FairSession
+}
+
+func NewFairSessionRepository() *FairSessionRepository {
+	return &FairSessionRepository{
+		sessions: map[string]*entity.FairSession{},
+	}
+}
+
+func (f *FairSessionRepository) Save(ctx context.Context, session *entity.FairSession) error {
+	stored := *session
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessions[session.SessionID] = &stored
+	return nil
+}
+
+func (f *FairSessionRepository) GetByID(ctx context.Context, sessionID string) (*entity.FairSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	session, ok := f.sessions[sessionID]
+	if !ok {
+		return nil, apperr.ErrNotFound
+	}
+	out := *session
+	return &out, nil
+}
+
+// GetActive returns the newest unrevealed session of the player in the room.
+func (f *FairSessionRepository) GetActive(ctx context.Context, roomID, playerID string) (*entity.FairSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var active *entity.FairSession
+	for _, s := range f.sessions {
+		if s.RoomID != roomID || s.PlayerID != playerID || s.IsRevealed() {
+			continue
+		}
+		if active == nil || s.CreatedAt > active.CreatedAt {
+			active = s
+		}
+	}
+	if active == nil {
+		return nil, apperr.ErrNotFound
+	}
+	out := *active
+	return &out, nil
+}
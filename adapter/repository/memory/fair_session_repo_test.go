@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+func TestFairSessionRepositoryRevealedSession(t *testing.T) {
+	ctx := context.Background()
+	repo := NewFairSessionRepository()
+	sessions := []*entity.FairSession{
+		{SessionID: "old", RoomID: "r1", PlayerID: "p1", CreatedAt: 1, RevealedAt: 5},
+		{SessionID: "new", RoomID: "r1", PlayerID: "p1", CreatedAt: 6},
+	}
+	for _, s := range sessions {
+		if err := repo.Save(ctx, s); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	active, err := repo.GetActive(ctx, "r1", "p1")
+	if err != nil || active.SessionID != "new" {
+		t.Fatalf("GetActive = %+v, %v; want session new", active, err)
+	}
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type fairShotKey struct {
+	sessionID string
+	nonce     uint64
+}
+
+type FairShotRepository struct {
+	mu    sync.RWMutex
+	shots map[fairShotKey]*entity.FairShot
+}
+
+func NewFairShotRepository() *FairShotRepository {
+	return &FairShotRepository{
+		shots: map[fairShotKey]*entity.FairShot{},
+	}
+}
+
+func (f *FairShotRepository) Save(ctx context.Context, shot *entity.FairShot) error {
+	stored := *shot
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.shots[fairShotKey{shot.SessionID, shot.Nonce}] = &stored
+	return nil
+}
+
+func (f *FairShotRepository) Get(ctx context.Context, sessionID string, nonce uint64) (*entity.FairShot, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	shot, ok := f.shots[fairShotKey{sessionID, nonce}]
+	if !ok {
+		return nil, apperr.ErrNotFound
+	}
+	out := *shot
+	return &out, nil
+}
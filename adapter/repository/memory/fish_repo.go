@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type FishRepository struct {
+	mu    sync.RWMutex
+	types map[int]*entity.FishType
+}
+
+func NewFishRepository() *FishRepository {
+	return &FishRepository{
+		types: map[int]*entity.FishType{},
+	}
+}
+
+func (f *FishRepository) GetTypeByID(ctx context.Context, fishID int) (*entity.FishType, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	fishType, ok := f.types[fishID]
+	if !ok {
+		return nil, apperr.ErrNotFound
+	}
+	out := *fishType
+	return &out, nil
+}
+
+// SaveType adds or replaces a fish type. The port is read-only; fixtures and
+// tests use this to seed it.
+func (f *FishRepository) SaveType(fishType *entity.FishType) {
+	stored := *fishType
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.types[fishType.FishID] = &stored
+}
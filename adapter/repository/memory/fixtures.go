@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+)
+
+// Fixtures is the JSON seed data of a Store. Game config documents are
+// grouped by kind ("bullets", "configs", ...) and use the same fields as the
+// MongoDB documents in docs/EXAMPLE_DOCUMENTS.md.
+type Fixtures struct {
+	FishTypes   []*entity.FishType           `json:"fish_types"`
+	Guns        []*entity.Gun                `json:"guns"`
+	Players     []*entity.Player             `json:"players"`
+	Rooms       []*entity.Room               `json:"rooms"`
+	RTP         map[string]*entity.RTPState  `json:"rtp"`
+	GameConfigs map[string][]json.RawMessage `json:"game_configs"`
+}
+
+// LoadFixtures reads fixtures from a JSON file.
+func LoadFixtures(path string) (*Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("parse fixtures %s: %w", path, err)
+	}
+	return &fixtures, nil
+}
+
+// Load adds the fixtures to the store, replacing records with the same key.
+func (s *Store) Load(f *Fixtures) error {
+	ctx := context.Background()
+	for _, fishType := range f.FishTypes {
+		s.Fish.SaveType(fishType)
+	}
+	for _, gun := range f.Guns {
+		s.Guns.Save(gun)
+	}
+	for _, player := range f.Players {
+		if err := s.Players.Save(ctx, player); err != nil {
+			return err
+		}
+	}
+	for _, room := range f.Rooms {
+		if err := s.Rooms.put(room); err != nil {
+			return err
+		}
+	}
+	for roomID, state := range f.RTP {
+		if err := s.RTP.Save(ctx, roomID, state); err != nil {
+			return err
+		}
+	}
+	for kind, docs := range f.GameConfigs {
+		for i, data := range docs {
+			doc, ok := gameBaseModels.NewConfigDocument(kind)
+			if !ok {
+				return fmt.Errorf("game_configs: unknown kind %q", kind)
+			}
+			if err := json.Unmarshal(data, doc); err != nil {
+				return fmt.Errorf("game_configs.%s[%d]: %w", kind, i, err)
+			}
+			if err := s.GameConfig.put(doc); err != nil {
+				return fmt.Errorf("game_configs.%s[%d]: %w", kind, i, err)
+			}
+		}
+	}
+	return nil
+}
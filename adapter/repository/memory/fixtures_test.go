@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+func TestStoreLoadsDevFixtures(t *testing.T) {
+	ctx := context.Background()
+	fixtures, err := LoadFixtures("../../../fixtures/memory.json")
+	if err != nil {
+		t.Fatalf("LoadFixtures: %v", err)
+	}
+	store := NewStore()
+	if err := store.Load(fixtures); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	gun, err := store.Guns.GetByID(ctx, 1)
+	if err != nil || gun.BulletCost <= 0 {
+		t.Fatalf("gun 1 = %+v, %v", gun, err)
+	}
+	if _, err := store.Fish.GetTypeByID(ctx, 1); err != nil {
+		t.Fatalf("fish type 1: %v", err)
+	}
+
+	games, err := store.GameConfig.ListGameNames(ctx)
+	if err != nil || len(games) != 1 {
+		t.Fatalf("ListGameNames = %v, %v; want one game", games, err)
+	}
+	rtp, err := store.GameConfig.GetGameRTP(ctx, games[0])
+	if err != nil {
+		t.Fatalf("GetGameRTP: %v", err)
+	}
+	if rtp.Data.FishRTPMap[1] == 0 {
+		t.Fatalf("fish rtp map not decoded: %+v", rtp.Data.FishRTPMap)
+	}
+	for _, kind := range gameBaseModels.ConfigKinds {
+		if _, err := store.GameConfig.GetDocument(ctx, kind, games[0]); err != nil {
+			t.Errorf("GetDocument %s: %v", kind, err)
+		}
+	}
+}
+
+func TestGameConfigRepositorySaveDocumentChecksVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := NewGameConfigRepository()
+	doc := &gameBaseModels.GameConfig{ConfigMeta: gameBaseModels.ConfigMeta{GameName: "g", Version: 1}}
+
+	if err := repo.SaveDocument(ctx, doc, 1); !errors.Is(err, apperr.ErrConfigVersionConflict) {
+		t.Fatalf("save over missing document at version 1: err = %v, want conflict", err)
+	}
+	if err := repo.SaveDocument(ctx, doc, 0); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+	doc.Version = 2
+	if err := repo.SaveDocument(ctx, doc, 0); !errors.Is(err, apperr.ErrConfigVersionConflict) {
+		t.Fatalf("stale save: err = %v, want conflict", err)
+	}
+	if err := repo.SaveDocument(ctx, doc, 1); err != nil {
+		t.Fatalf("save from version 1: %v", err)
+	}
+
+	got, err := repo.GetGameConfig(ctx, "g")
+	if err != nil || got.Version != 2 {
+		t.Fatalf("GetGameConfig = %+v, %v; want version 2", got, err)
+	}
+}
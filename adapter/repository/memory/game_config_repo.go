@@ -0,0 +1,158 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type configKey struct {
+	kind     string
+	gameName string
+}
+
+// GameConfigRepository keeps game config documents in their BSON form, one
+// per kind and game, like the MongoDB collections it stands in for. Nothing
+// is cached in front of it, so Invalidate has nothing to drop.
+type GameConfigRepository struct {
+	mu   sync.RWMutex
+	docs map[configKey]bson.Raw
+}
+
+func NewGameConfigRepository() *GameConfigRepository {
+	return &GameConfigRepository{
+		docs: map[configKey]bson.Raw{},
+	}
+}
+
+func (r *GameConfigRepository) GetBulletConfig(ctx context.Context, gameName string) (*gameBaseModels.BulletConfig, error) {
+	var config gameBaseModels.BulletConfig
+	if err := r.decode(gameBaseModels.ConfigKindBullets, gameName, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (r *GameConfigRepository) GetGameConfig(ctx context.Context, gameName string) (*gameBaseModels.GameConfig, error) {
+	var config gameBaseModels.GameConfig
+	if err := r.decode(gameBaseModels.ConfigKindConfigs, gameName, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (r *GameConfigRepository) GetGameFeatures(ctx context.Context, gameName string) (*gameBaseModels.GameFeatures, error) {
+	var features gameBaseModels.GameFeatures
+	if err := r.decode(gameBaseModels.ConfigKindFeatures, gameName, &features); err != nil {
+		return nil, err
+	}
+	return &features, nil
+}
+
+func (r *GameConfigRepository) GetGamePaths(ctx context.Context, gameName string) (*gameBaseModels.GamePaths, error) {
+	var paths gameBaseModels.GamePaths
+	if err := r.decode(gameBaseModels.ConfigKindPaths, gameName, &paths); err != nil {
+		return nil, err
+	}
+	return &paths, nil
+}
+
+func (r *GameConfigRepository) GetGameRTP(ctx context.Context, gameName string) (*gameBaseModels.GameRTP, error) {
+	var rtp gameBaseModels.GameRTP
+	if err := r.decode(gameBaseModels.ConfigKindRTPs, gameName, &rtp); err != nil {
+		return nil, err
+	}
+	return &rtp, nil
+}
+
+func (r *GameConfigRepository) GetGameFishTypes(ctx context.Context, gameName string) (*gameBaseModels.GameFishTypes, error) {
+	var fishTypes gameBaseModels.GameFishTypes
+	if err := r.decode(gameBaseModels.ConfigKindFishTypes, gameName, &fishTypes); err != nil {
+		return nil, err
+	}
+	return &fishTypes, nil
+}
+
+func (r *GameConfigRepository) GetDocument(ctx context.Context, kind, gameName string) (gameBaseModels.ConfigDocument, error) {
+	doc, ok := gameBaseModels.NewConfigDocument(kind)
+	if !ok {
+		return nil, apperr.ErrUnknownConfigKind
+	}
+	if err := r.decode(kind, gameName, doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (r *GameConfigRepository) SaveDocument(ctx context.Context, doc gameBaseModels.ConfigDocument, prevVersion int64) error {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	key := configKey{kind: doc.Kind(), gameName: doc.Meta().GameName}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current, exists := r.docs[key]
+	storedVersion := int64(0)
+	if exists {
+		var meta gameBaseModels.ConfigMeta
+		if err := bson.Unmarshal(current, &meta); err != nil {
+			return err
+		}
+		storedVersion = meta.Version
+	}
+	if storedVersion != prevVersion {
+		return apperr.ErrConfigVersionConflict
+	}
+	r.docs[key] = raw
+	return nil
+}
+
+func (r *GameConfigRepository) ListGameNames(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	seen := map[string]bool{}
+	for key := range r.docs {
+		if key.gameName != "" {
+			seen[key.gameName] = true
+		}
+	}
+	r.mu.RUnlock()
+
+	gameNames := make([]string, 0, len(seen))
+	for name := range seen {
+		gameNames = append(gameNames, name)
+	}
+	sort.Strings(gameNames)
+	return gameNames, nil
+}
+
+func (r *GameConfigRepository) Invalidate(ctx context.Context, kind, gameName string) error {
+	return nil
+}
+
+func (r *GameConfigRepository) decode(kind, gameName string, out interface{}) error {
+	r.mu.RLock()
+	raw, ok := r.docs[configKey{kind: kind, gameName: gameName}]
+	r.mu.RUnlock()
+	if !ok {
+		return apperr.ErrNotFound
+	}
+	return bson.Unmarshal(raw, out)
+}
+
+// put stores a document regardless of its version, for seeding.
+func (r *GameConfigRepository) put(doc gameBaseModels.ConfigDocument) error {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.docs[configKey{kind: doc.Kind(), gameName: doc.Meta().GameName}] = raw
+	return nil
+}
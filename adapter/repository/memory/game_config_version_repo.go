@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type versionKey struct {
+	kind     string
+	gameName string
+	version  int64
+}
+
+// versionRecord is the stored form of a ConfigVersion. The document is kept
+// raw because its concrete type depends on Kind.
+type versionRecord struct {
+	author    string
+	createdAt int64
+	document  bson.Raw
+}
+
+type GameConfigVersionRepository struct {
+	mu       sync.RWMutex
+	versions map[versionKey]versionRecord
+	rollouts map[configKey]gameBaseModels.ConfigRollout
+}
+
+func NewGameConfigVersionRepository() *GameConfigVersionRepository {
+	return &GameConfigVersionRepository{
+		versions: map[versionKey]versionRecord{},
+		rollouts: map[configKey]gameBaseModels.ConfigRollout{},
+	}
+}
+
+func (r *GameConfigVersionRepository) SaveVersion(ctx context.Context, version *gameBaseModels.ConfigVersion) error {
+	raw, err := bson.Marshal(version.Document)
+	if err != nil {
+		return err
+	}
+	key := versionKey{kind: version.Kind, gameName: version.GameName, version: version.Version}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.versions[key]; exists {
+		return apperr.ErrConfigVersionConflict
+	}
+	r.versions[key] = versionRecord{author: version.Author, createdAt: version.CreatedAt, document: raw}
+	return nil
+}
+
+func (r *GameConfigVersionRepository) GetVersion(ctx context.Context, kind, gameName string, version int64) (*gameBaseModels.ConfigVersion, error) {
+	key := versionKey{kind: kind, gameName: gameName, version: version}
+	r.mu.RLock()
+	record, ok := r.versions[key]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, apperr.ErrNotFound
+	}
+	return record.toModel(key)
+}
+
+func (r *GameConfigVersionRepository) ListVersions(ctx context.Context, kind, gameName string, limit int) ([]*gameBaseModels.ConfigVersion, error) {
+	r.mu.RLock()
+	keys := []versionKey{}
+	for key := range r.versions {
+		if key.kind == kind && key.gameName == gameName {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].version > keys[j].version
+	})
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+	records := make([]versionRecord, len(keys))
+	for i, key := range keys {
+		records[i] = r.versions[key]
+	}
+	r.mu.RUnlock()
+
+	versions := make([]*gameBaseModels.ConfigVersion, 0, len(keys))
+	for i, key := range keys {
+		v, err := records[i].toModel(key)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+func (r *GameConfigVersionRepository) GetRollout(ctx context.Context, kind, gameName string) (*gameBaseModels.ConfigRollout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rollout, ok := r.rollouts[configKey{kind: kind, gameName: gameName}]
+	if !ok {
+		return nil, apperr.ErrNotFound
+	}
+	return &rollout, nil
+}
+
+func (r *GameConfigVersionRepository) SaveRollout(ctx context.Context, rollout *gameBaseModels.ConfigRollout) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rollouts[configKey{kind: rollout.Kind, gameName: rollout.GameName}] = *rollout
+	return nil
+}
+
+func (rec versionRecord) toModel(key versionKey) (*gameBaseModels.ConfigVersion, error) {
+	doc, ok := gameBaseModels.NewConfigDocument(key.kind)
+	if !ok {
+		return nil, apperr.ErrUnknownConfigKind
+	}
+	if err := bson.Unmarshal(rec.document, doc); err != nil {
+		return nil, err
+	}
+	return &gameBaseModels.ConfigVersion{
+		Kind:      key.kind,
+		GameName:  key.gameName,
+		Version:   key.version,
+		Author:    rec.author,
+		CreatedAt: rec.createdAt,
+		Document:  doc,
+	}, nil
+}
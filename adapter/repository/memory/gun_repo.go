@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type GunRepository struct {
+	mu   sync.RWMutex
+	guns map[int]*entity.Gun
+}
+
+func NewGunRepository() *GunRepository {
+	return &GunRepository{
+		guns: map[int]*entity.Gun{},
+	}
+}
+
+func (g *GunRepository) GetByID(ctx context.Context, gunID int) (*entity.Gun, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	gun, ok := g.guns[gunID]
+	if !ok {
+		return nil, apperr.ErrNotFound
+	}
+	out := *gun
+	return &out, nil
+}
+
+// Save adds or replaces a gun. The port is read-only; fixtures and tests use
+// this to seed it.
+func (g *GunRepository) Save(gun *entity.Gun) {
+	stored := *gun
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.guns[gun.GunID] = &stored
+}
@@ -0,0 +1,41 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type PlayerRepository struct {
+	mu      sync.RWMutex
+	players map[string]*entity.Player
+}
+
+func NewPlayerRepository() *PlayerRepository {
+	return &PlayerRepository{
+		players: map[string]*entity.Player{},
+	}
+}
+
+func (p *PlayerRepository) GetByID(ctx context.Context, playerID string) (*entity.Player, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	player, ok := p.players[playerID]
+	if !ok {
+		return nil, apperr.ErrNotFound
+	}
+	return cloneBSON(player)
+}
+
+func (p *PlayerRepository) Save(ctx context.Context, player *entity.Player) error {
+	stored, err := cloneBSON(player)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.players[player.PlayerID] = stored
+	return nil
+}
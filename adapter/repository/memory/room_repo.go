@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type RoomRepository struct {
+	mu    sync.RWMutex
+	rooms map[string]*entity.Room
+}
+
+func NewRoomRepository() *RoomRepository {
+	return &RoomRepository{
+		rooms: map[string]*entity.Room{},
+	}
+}
+
+func (r *RoomRepository) GetByID(ctx context.Context, roomID string) (*entity.Room, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	room, ok := r.rooms[roomID]
+	if !ok {
+		return nil, apperr.ErrNotFound
+	}
+	return cloneBSON(room)
+}
+
+func (r *RoomRepository) Save(ctx context.Context, room *entity.Room) error {
+	stored, err := cloneBSON(room)
+	if err != nil {
+		return err
+	}
+	stored.BulletsExpireAt = stored.NextBulletExpiry()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current, ok := r.rooms[room.RoomID]
+	if (ok && current.Seq != room.Seq-1) || (!ok && room.Seq != 1) {
+		return apperr.ErrRoomConflict
+	}
+	r.rooms[room.RoomID] = stored
+	return nil
+}
+
+// put stores a fixture room as is, whatever its Seq.
+func (r *RoomRepository) put(room *entity.Room) error {
+	stored, err := cloneBSON(room)
+	if err != nil {
+		return err
+	}
+	stored.BulletsExpireAt = stored.NextBulletExpiry()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rooms[room.RoomID] = stored
+	return nil
+}
+
+// ListWithExpiredBullets returns the rooms whose earliest bullet expired at
+// or before nowMs, earliest first.
+func (r *RoomRepository) ListWithExpiredBullets(ctx context.Context, nowMs int64, limit int) ([]string, error) {
+	r.mu.RLock()
+	var due []*entity.Room
+	for _, room := range r.rooms {
+		if room.BulletsExpireAt > 0 && room.BulletsExpireAt <= nowMs {
+			due = append(due, room)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].BulletsExpireAt != due[j].BulletsExpireAt {
+			return due[i].BulletsExpireAt < due[j].BulletsExpireAt
+		}
+		return due[i].RoomID < due[j].RoomID
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	roomIDs := make([]string, len(due))
+	for i, room := range due {
+		roomIDs[i] = room.RoomID
+	}
+	return roomIDs, nil
+}
+
+// CountActive counts rooms that are not closed and have at least one seated
+// player, and the players seated in them.
+func (r *RoomRepository) CountActive(ctx context.Context) (rooms, players int64, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, room := range r.rooms {
+		if room.Status == string(entity.RoomStatusClosed) || len(room.Players) == 0 {
+			continue
+		}
+		rooms++
+		players += int64(len(room.Players))
+	}
+	return rooms, players, nil
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+func TestRoomRepositoryReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRoomRepository()
+
+	room := &entity.Room{
+		RoomID:  "room-1",
+		Status:  string(entity.RoomStatusOpen),
+		Players: map[string]*entity.Player{"p1": {PlayerID: "p1", Balance: 100}},
+		Seq:     1,
+	}
+	if err := repo.Save(ctx, room); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	room.Players["p1"].Balance = 0
+
+	got, err := repo.GetByID(ctx, "room-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Players["p1"].Balance != 100 {
+		t.Fatalf("stored room changed with the saved value: balance %d", got.Players["p1"].Balance)
+	}
+
+	got.Players["p2"] = &entity.Player{PlayerID: "p2"}
+	again, err := repo.GetByID(ctx, "room-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if again.HasPlayer("p2") {
+		t.Fatal("stored room changed with a returned value")
+	}
+}
+
+func TestRoomRepositoryCountActive(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRoomRepository()
+	seated := map[string]*entity.Player{"a": {PlayerID: "a"}, "b": {PlayerID: "b"}}
+	rooms := []*entity.Room{
+		{RoomID: "open", Status: string(entity.RoomStatusOpen), Players: seated},
+		{RoomID: "running", Status: string(entity.RoomStatusRunning), Players: map[string]*entity.Player{"c": {PlayerID: "c"}}},
+		{RoomID: "empty", Status: string(entity.RoomStatusOpen)},
+		{RoomID: "closed", Status: string(entity.RoomStatusClosed), Players: seated},
+	}
+	for _, room := range rooms {
+		room.NextSeq()
+		if err := repo.Save(ctx, room); err != nil {
+			t.Fatalf("Save %s: %v", room.RoomID, err)
+		}
+	}
+
+	active, players, err := repo.CountActive(ctx)
+	if err != nil {
+		t.Fatalf("CountActive: %v", err)
+	}
+	if active != 2 || players != 3 {
+		t.Fatalf("CountActive = %d rooms, %d players; want 2, 3", active, players)
+	}
+}
@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type RTPRepository struct {
+	mu     sync.RWMutex
+	states map[string]entity.RTPState
+}
+
+func NewRTPRepository() *RTPRepository {
+	return &RTPRepository{
+		states: map[string]entity.RTPState{},
+	}
+}
+
+func (r *RTPRepository) GetByRoomID(ctx context.Context, roomID string) (*entity.RTPState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	state, ok := r.states[roomID]
+	if !ok {
+		return nil, apperr.ErrNotFound
+	}
+	return &state, nil
+}
+
+func (r *RTPRepository) Save(ctx context.Context, roomID string, state *entity.RTPState) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[roomID] = *state
+	return nil
+}
@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+type shotEntry struct {
+	result    *entity.ShotResult // nil while the claim is pending
+	expiresAt time.Time
+}
+
+type ShotResultRepository struct {
+	mu      sync.Mutex
+	entries map[string]*shotEntry
+	now     func() time.Time
+}
+
+func NewShotResultRepository() *ShotResultRepository {
+	return &ShotResultRepository{
+		entries: map[string]*shotEntry{},
+		now:     time.Now,
+	}
+}
+
+func (r *ShotResultRepository) key(playerID, bulletID string) string {
+	return playerID + ":" + bulletID
+}
+
+// entry returns the live entry for key, dropping it if it has expired. It
+// must be called with r.mu held.
+func (r *ShotResultRepository) entry(key string) *shotEntry {
+	e, ok := r.entries[key]
+	if !ok {
+		return nil
+	}
+	if !r.now().Before(e.expiresAt) {
+		delete(r.entries, key)
+		return nil
+	}
+	return e
+}
+
+func (r *ShotResultRepository) Claim(ctx context.Context, playerID, bulletID string, ttl time.Duration) (bool, *entity.ShotResult, error) {
+	key := r.key(playerID, bulletID)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := r.entry(key)
+	if e == nil {
+		r.entries[key] = &shotEntry{expiresAt: r.now().Add(ttl)}
+		return true, nil, nil
+	}
+	if e.result == nil {
+		return false, nil, nil
+	}
+	result, err := cloneJSON(e.result)
+	if err != nil {
+		return false, nil, err
+	}
+	return false, result, nil
+}
+
+func (r *ShotResultRepository) Complete(ctx context.Context, playerID, bulletID string, result *entity.ShotResult, ttl time.Duration) error {
+	stored, err := cloneJSON(result)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[r.key(playerID, bulletID)] = &shotEntry{result: stored, expiresAt: r.now().Add(ttl)}
+	return nil
+}
+
+// Release drops a claim only while it is still pending, so a late Release
+// cannot wipe a completed result.
+func (r *ShotResultRepository) Release(ctx context.Context, playerID, bulletID string) error {
+	key := r.key(playerID, bulletID)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e := r.entry(key); e != nil && e.result == nil {
+		delete(r.entries, key)
+	}
+	return nil
+}
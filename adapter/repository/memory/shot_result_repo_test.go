@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+func TestShotResultRepositoryClaimLifecycle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	repo := NewShotResultRepository()
+	repo.now = func() time.Time { return now }
+
+	claimed, _, err := repo.Claim(ctx, "p1", "b1", time.Minute)
+	if err != nil || !claimed {
+		t.Fatalf("first Claim = %v, %v; want claimed", claimed, err)
+	}
+	claimed, result, err := repo.Claim(ctx, "p1", "b1", time.Minute)
+	if err != nil || claimed || result != nil {
+		t.Fatalf("Claim while pending = %v, %v, %v; want in flight", claimed, result, err)
+	}
+
+	if err := repo.Complete(ctx, "p1", "b1", &entity.ShotResult{RoomID: "room-1", Cost: 10}, time.Minute); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	// A late Release must not drop the completed result.
+	if err := repo.Release(ctx, "p1", "b1"); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	claimed, result, err = repo.Claim(ctx, "p1", "b1", time.Minute)
+	if err != nil || claimed || result == nil || result.Cost != 10 {
+		t.Fatalf("Claim after Complete = %v, %+v, %v; want stored result", claimed, result, err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	claimed, _, err = repo.Claim(ctx, "p1", "b1", time.Minute)
+	if err != nil || !claimed {
+		t.Fatalf("Claim after expiry = %v, %v; want claimed", claimed, err)
+	}
+}
@@ -0,0 +1,39 @@
+// Package memory implements every repository port in process memory. The
+// repositories are safe for concurrent use and hand out copies, so callers
+// see the same isolation as with the MongoDB and Redis adapters. They back
+// STORAGE=memory and tests.
+package memory
+
+// Store holds one instance of every repository so they all see the same
+// data, the way the MongoDB repositories share one database.
+type Store struct {
+	Rooms              *RoomRepository
+	Players            *PlayerRepository
+	Fish               *FishRepository
+	Guns               *GunRepository
+	RTP                *RTPRepository
+	ShotResults        *ShotResultRepository
+	GameConfig         *GameConfigRepository
+	GameConfigVersions *GameConfigVersionRepository
+	WalletTransfers    *WalletTransferRepository
+	FairSessions       *FairSessionRepository
+	FairShots          *FairShotRepository
+	Events             *EventStore
+}
+
+func NewStore() *Store {
+	return &Store{
+		Rooms:              NewRoomRepository(),
+		Players:            NewPlayerRepository(),
+		Fish:               NewFishRepository(),
+		Guns:               NewGunRepository(),
+		RTP:                NewRTPRepository(),
+		ShotResults:        NewShotResultRepository(),
+		GameConfig:         NewGameConfigRepository(),
+		GameConfigVersions: NewGameConfigVersionRepository(),
+		WalletTransfers:    NewWalletTransferRepository(),
+		FairSessions:       NewFairSessionRepository(),
+		FairShots:          NewFairShotRepository(),
+		Events:             NewEventStore(),
+	}
+}
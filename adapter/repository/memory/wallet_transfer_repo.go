@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+type WalletTransferRepository struct {
+	mu        sync.RWMutex
+	transfers map[string]*entity.WalletTransfer
+}
+
+func NewWalletTransferRepository() *WalletTransferRepository {
+	return &WalletTransferRepository{
+		transfers: map[string]*entity.WalletTransfer{},
+	}
+}
+
+func (w *WalletTransferRepository) Save(ctx context.Context, transfer *entity.WalletTransfer) error {
+	stored := *transfer
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.transfers[transfer.TxID] = &stored
+	return nil
+}
+
+func (w *WalletTransferRepository) ListPending(ctx context.Context, updatedBefore int64, limit int) ([]*entity.WalletTransfer, error) {
+	w.mu.RLock()
+	transfers := []*entity.WalletTransfer{}
+	for _, t := range w.transfers {
+		if t.Status == entity.WalletTransferPending && t.UpdatedAt < updatedBefore {
+			out := *t
+			transfers = append(transfers, &out)
+		}
+	}
+	w.mu.RUnlock()
+
+	sort.Slice(transfers, func(i, j int) bool {
+		return transfers[i].UpdatedAt < transfers[j].UpdatedAt
+	})
+	if limit > 0 && len(transfers) > limit {
+		transfers = transfers[:limit]
+	}
+	return transfers, nil
+}
@@ -0,0 +1,119 @@
+package mongo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port/porttest"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	connectOnce sync.Once
+	testClient  *mongo.Client
+	connectErr  error
+)
+
+// testDatabase returns a fresh database on the server at MONGO_URI, dropped
+// when the test ends. The server is dialled once per run, and every test is
+// skipped if it does not answer.
+func testDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		uri = "mongodb://localhost:27017"
+	}
+	connectOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		testClient, connectErr = mongo.Connect(ctx, options.Client().ApplyURI(uri).SetServerSelectionTimeout(2*time.Second))
+		if connectErr == nil {
+			connectErr = testClient.Ping(ctx, nil)
+		}
+	})
+	if connectErr != nil {
+		t.Skipf("MongoDB not available at %s: %v", uri, connectErr)
+	}
+
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("random database name: %v", err)
+	}
+	db := testClient.Database("porttest_" + hex.EncodeToString(b))
+	t.Cleanup(func() { _ = db.Drop(context.Background()) })
+	return db
+}
+
+func TestRoomRepositoryContract(t *testing.T) {
+	porttest.RoomRepository(t, func(t *testing.T) port.RoomRepository {
+		repo := NewRoomRepository(testDatabase(t))
+		if err := repo.EnsureIndexes(context.Background()); err != nil {
+			t.Fatalf("EnsureIndexes: %v", err)
+		}
+		return repo
+	})
+}
+
+func TestPlayerRepositoryContract(t *testing.T) {
+	porttest.PlayerRepository(t, func(t *testing.T) port.PlayerRepository { return NewPlayerRepository(testDatabase(t)) })
+}
+
+func TestFishRepositoryContract(t *testing.T) {
+	porttest.FishRepository(t, func(t *testing.T, seed []*entity.FishType) port.FishRepository {
+		repo := NewFishRepository(testDatabase(t))
+		for _, fishType := range seed {
+			if _, err := repo.collection.InsertOne(context.Background(), fishType); err != nil {
+				t.Fatalf("seed fish type %d: %v", fishType.FishID, err)
+			}
+		}
+		return repo
+	})
+}
+
+func TestGunRepositoryContract(t *testing.T) {
+	porttest.GunRepository(t, func(t *testing.T, seed []*entity.Gun) port.GunRepository {
+		repo := NewGunRepository(testDatabase(t))
+		for _, gun := range seed {
+			if _, err := repo.collection.InsertOne(context.Background(), gun); err != nil {
+				t.Fatalf("seed gun %d: %v", gun.GunID, err)
+			}
+		}
+		return repo
+	})
+}
+
+func TestGameConfigStoreContract(t *testing.T) {
+	porttest.GameConfigStore(t, func(t *testing.T) port.GameConfigStore { return NewGameConfigRepository(testDatabase(t)) })
+}
+
+func TestGameConfigVersionStoreContract(t *testing.T) {
+	porttest.GameConfigVersionStore(t, func(t *testing.T) port.GameConfigVersionStore {
+		return NewGameConfigVersionRepository(testDatabase(t))
+	})
+}
+
+func TestWalletTransferRepositoryContract(t *testing.T) {
+	porttest.WalletTransferRepository(t, func(t *testing.T) port.WalletTransferRepository {
+		return NewWalletTransferRepository(testDatabase(t))
+	})
+}
+
+func TestFairSessionRepositoryContract(t *testing.T) {
+	porttest.FairSessionRepository(t, func(t *testing.T) port.FairSessionRepository { return NewFairSessionRepository(testDatabase(t)) })
+}
+
+func TestFairShotRepositoryContract(t *testing.T) {
+	porttest.FairShotRepository(t, func(t *testing.T) port.FairShotRepository { return NewFairShotRepository(testDatabase(t)) })
+}
+
+func TestEventStoreContract(t *testing.T) {
+	porttest.EventStore(t, func(t *testing.T) port.EventStore { return NewEventStore(testDatabase(t)) })
+}
@@ -0,0 +1,71 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type EventStore struct {
+	events   *mongo.Collection
+	counters *mongo.Collection
+}
+
+func NewEventStore(db *mongo.Database) *EventStore {
+	return &EventStore{
+		events:   db.Collection("room_events"),
+		counters: db.Collection("room_event_counters"),
+	}
+}
+
+// Append reserves a block of sequence numbers from the room's counter
+// document before inserting, so concurrent writers never share a seq.
+func (s *EventStore) Append(ctx context.Context, roomID string, events ...*entity.GameEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var counter struct {
+		Seq int64 `bson:"seq"`
+	}
+	err := s.counters.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": roomID},
+		bson.M{"$inc": bson.M{"seq": int64(len(events))}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	if err != nil {
+		return err
+	}
+
+	first := counter.Seq - int64(len(events)) + 1
+	docs := make([]interface{}, len(events))
+	for i, e := range events {
+		e.RoomID = roomID
+		e.Seq = first + int64(i)
+		docs[i] = e
+	}
+	_, err = s.events.InsertMany(ctx, docs)
+	return err
+}
+
+func (s *EventStore) List(ctx context.Context, roomID string, fromSeq, toSeq int64) ([]*entity.GameEvent, error) {
+	seq := bson.M{"$gte": fromSeq}
+	if toSeq > 0 {
+		seq["$lte"] = toSeq
+	}
+	opts := options.Find().SetSort(bson.M{"seq": 1})
+	cursor, err := s.events.Find(ctx, bson.M{"room_id": roomID, "seq": seq}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*entity.GameEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,58 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FairSessionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFairSessionRepository(db *mongo.Database) *FairSessionRepository {
+	return &FairSessionRepository{
+		collection: db.Collection("fair_sessions"),
+	}
+}
+
+func (f *FairSessionRepository) Save(ctx context.Context, session *entity.FairSession) error {
+	opts := options.Update().SetUpsert(true)
+	_, err := f.collection.UpdateOne(
+		ctx,
+		bson.M{"session_id": session.SessionID},
+		bson.M{"$set": session},
+		opts,
+	)
+	return err
+}
+
+func (f *FairSessionRepository) GetByID(ctx context.Context, sessionID string) (*entity.FairSession, error) {
+	return f.findOne(ctx, bson.M{"session_id": sessionID}, nil)
+}
+
+func (f *FairSessionRepository) GetActive(ctx context.Context, roomID, playerID string) (*entity.FairSession, error) {
+	opts := options.FindOne().SetSort(bson.M{"created_at": -1})
+	return f.findOne(ctx, bson.M{"room_id": roomID, "player_id": playerID, "revealed_at": 0}, opts)
+}
+
+func (f *FairSessionRepository) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*entity.FairSession, error) {
+	var session entity.FairSession
+	var err error
+	if opts != nil {
+		err = f.collection.FindOne(ctx, filter, opts).Decode(&session)
+	} else {
+		err = f.collection.FindOne(ctx, filter).Decode(&session)
+	}
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
@@ -0,0 +1,44 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FairShotRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFairShotRepository(db *mongo.Database) *FairShotRepository {
+	return &FairShotRepository{
+		collection: db.Collection("fair_shots"),
+	}
+}
+
+func (f *FairShotRepository) Save(ctx context.Context, shot *entity.FairShot) error {
+	opts := options.Update().SetUpsert(true)
+	_, err := f.collection.UpdateOne(
+		ctx,
+		bson.M{"session_id": shot.SessionID, "nonce": shot.Nonce},
+		bson.M{"$set": shot},
+		opts,
+	)
+	return err
+}
+
+func (f *FairShotRepository) Get(ctx context.Context, sessionID string, nonce uint64) (*entity.FairShot, error) {
+	var shot entity.FairShot
+	err := f.collection.FindOne(ctx, bson.M{"session_id": sessionID, "nonce": nonce}).Decode(&shot)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &shot, nil
+}
@@ -3,19 +3,20 @@ package mongo
 import (
 	"context"
 	"errors"
+	"sort"
 
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
-	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
 	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type GameConfigRepository struct {
 	db *mongo.Database
 }
 
-func NewGameConfigRepository(db *mongo.Database) port.GameConfigRepository {
+func NewGameConfigRepository(db *mongo.Database) *GameConfigRepository {
 	return &GameConfigRepository{
 		db: db,
 	}
@@ -110,3 +111,75 @@ func (r *GameConfigRepository) GetGameFishTypes(ctx context.Context, gameName st
 
 	return &fishTypes, nil
 }
+
+func (r *GameConfigRepository) GetDocument(ctx context.Context, kind, gameName string) (gameBaseModels.ConfigDocument, error) {
+	doc, ok := gameBaseModels.NewConfigDocument(kind)
+	if !ok {
+		return nil, apperr.ErrUnknownConfigKind
+	}
+
+	err := r.db.Collection(kind).FindOne(ctx, bson.M{"game_name": gameName}).Decode(doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func (r *GameConfigRepository) SaveDocument(ctx context.Context, doc gameBaseModels.ConfigDocument, prevVersion int64) error {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	var set bson.M
+	if err := bson.Unmarshal(raw, &set); err != nil {
+		return err
+	}
+	delete(set, "_id")
+
+	// Documents written before versioning have no version field, which
+	// matches a null filter.
+	filter := bson.M{"game_name": doc.Meta().GameName, "version": prevVersion}
+	if prevVersion == 0 {
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	result, err := r.db.Collection(doc.Kind()).UpdateOne(
+		ctx,
+		filter,
+		bson.M{"$set": set},
+		options.Update().SetUpsert(prevVersion == 0),
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 && result.UpsertedCount == 0 {
+		return apperr.ErrConfigVersionConflict
+	}
+	return nil
+}
+
+func (r *GameConfigRepository) ListGameNames(ctx context.Context) ([]string, error) {
+	seen := map[string]bool{}
+	for _, kind := range gameBaseModels.ConfigKinds {
+		names, err := r.db.Collection(kind).Distinct(ctx, "game_name", bson.M{})
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if s, ok := name.(string); ok && s != "" {
+				seen[s] = true
+			}
+		}
+	}
+
+	gameNames := make([]string, 0, len(seen))
+	for name := range seen {
+		gameNames = append(gameNames, name)
+	}
+	sort.Strings(gameNames)
+	return gameNames, nil
+}
@@ -0,0 +1,141 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type GameConfigVersionRepository struct {
+	versions *mongo.Collection
+	rollouts *mongo.Collection
+}
+
+func NewGameConfigVersionRepository(db *mongo.Database) *GameConfigVersionRepository {
+	return &GameConfigVersionRepository{
+		versions: db.Collection("config_versions"),
+		rollouts: db.Collection("config_rollouts"),
+	}
+}
+
+// versionRecord is the stored form of a ConfigVersion. The document is kept
+// raw because its concrete type depends on Kind.
+type versionRecord struct {
+	Kind      string   `bson:"kind"`
+	GameName  string   `bson:"game_name"`
+	Version   int64    `bson:"version"`
+	Author    string   `bson:"author"`
+	CreatedAt int64    `bson:"created_at"`
+	Document  bson.Raw `bson:"document"`
+}
+
+func (r *GameConfigVersionRepository) SaveVersion(ctx context.Context, version *gameBaseModels.ConfigVersion) error {
+	raw, err := bson.Marshal(version.Document)
+	if err != nil {
+		return err
+	}
+	record := versionRecord{
+		Kind:      version.Kind,
+		GameName:  version.GameName,
+		Version:   version.Version,
+		Author:    version.Author,
+		CreatedAt: version.CreatedAt,
+		Document:  raw,
+	}
+
+	// $setOnInsert makes the write insert-only: an existing version is left
+	// untouched and reported as a conflict.
+	result, err := r.versions.UpdateOne(
+		ctx,
+		bson.M{"kind": version.Kind, "game_name": version.GameName, "version": version.Version},
+		bson.M{"$setOnInsert": record},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return err
+	}
+	if result.UpsertedCount == 0 {
+		return apperr.ErrConfigVersionConflict
+	}
+	return nil
+}
+
+func (r *GameConfigVersionRepository) GetVersion(ctx context.Context, kind, gameName string, version int64) (*gameBaseModels.ConfigVersion, error) {
+	var record versionRecord
+	err := r.versions.FindOne(ctx, bson.M{"kind": kind, "game_name": gameName, "version": version}).Decode(&record)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, err
+	}
+	return record.toModel()
+}
+
+func (r *GameConfigVersionRepository) ListVersions(ctx context.Context, kind, gameName string, limit int) ([]*gameBaseModels.ConfigVersion, error) {
+	opts := options.Find().SetSort(bson.M{"version": -1}).SetLimit(int64(limit))
+	cursor, err := r.versions.Find(ctx, bson.M{"kind": kind, "game_name": gameName}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []versionRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	versions := make([]*gameBaseModels.ConfigVersion, 0, len(records))
+	for i := range records {
+		v, err := records[i].toModel()
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+func (r *GameConfigVersionRepository) GetRollout(ctx context.Context, kind, gameName string) (*gameBaseModels.ConfigRollout, error) {
+	var rollout gameBaseModels.ConfigRollout
+	err := r.rollouts.FindOne(ctx, bson.M{"kind": kind, "game_name": gameName}).Decode(&rollout)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &rollout, nil
+}
+
+func (r *GameConfigVersionRepository) SaveRollout(ctx context.Context, rollout *gameBaseModels.ConfigRollout) error {
+	opts := options.Update().SetUpsert(true)
+	_, err := r.rollouts.UpdateOne(
+		ctx,
+		bson.M{"kind": rollout.Kind, "game_name": rollout.GameName},
+		bson.M{"$set": rollout},
+		opts,
+	)
+	return err
+}
+
+func (rec *versionRecord) toModel() (*gameBaseModels.ConfigVersion, error) {
+	doc, ok := gameBaseModels.NewConfigDocument(rec.Kind)
+	if !ok {
+		return nil, apperr.ErrUnknownConfigKind
+	}
+	if err := bson.Unmarshal(rec.Document, doc); err != nil {
+		return nil, err
+	}
+	return &gameBaseModels.ConfigVersion{
+		Kind:      rec.Kind,
+		GameName:  rec.GameName,
+		Version:   rec.Version,
+		Author:    rec.Author,
+		CreatedAt: rec.CreatedAt,
+		Document:  doc,
+	}, nil
+}
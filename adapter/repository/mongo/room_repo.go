@@ -32,13 +32,110 @@ func (r *RoomRepository) GetByID(ctx context.Context, roomID string) (*entity.Ro
 	return &room, nil
 }
 
+// EnsureIndexes creates the index ListWithExpiredBullets reads. Rooms with
+// no bullets in flight are left out of it.
+func (r *RoomRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "bullets_expire_at", Value: 1}},
+		Options: options.Index().
+			SetName("bullets_expire_at").
+			SetPartialFilterExpression(bson.M{"bullets_expire_at": bson.M{"$gt": 0}}),
+	})
+	return err
+}
+
 func (r *RoomRepository) Save(ctx context.Context, room *entity.Room) error {
-	opts := options.Update().SetUpsert(true)
-	_, err := r.collection.UpdateOne(
+	room.BulletsExpireAt = room.NextBulletExpiry()
+
+	// Rooms written before the seq field existed match a null filter.
+	prevSeq := room.Seq - 1
+	filter := bson.M{"room_id": room.RoomID, "seq": prevSeq}
+	if prevSeq == 0 {
+		filter["seq"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": room})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+	if prevSeq != 0 {
+		return apperr.ErrRoomConflict
+	}
+
+	// $setOnInsert makes the write insert-only, so a room created by
+	// another request in the meantime is left untouched.
+	result, err = r.collection.UpdateOne(
 		ctx,
 		bson.M{"room_id": room.RoomID},
-		bson.M{"$set": room},
-		opts,
+		bson.M{"$setOnInsert": room},
+		options.Update().SetUpsert(true),
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if result.UpsertedCount == 0 {
+		return apperr.ErrRoomConflict
+	}
+	return nil
+}
+
+// ListWithExpiredBullets returns the rooms whose earliest bullet expired at
+// or before nowMs, earliest first.
+func (r *RoomRepository) ListWithExpiredBullets(ctx context.Context, nowMs int64, limit int) ([]string, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "bullets_expire_at", Value: 1}}).
+		SetProjection(bson.M{"room_id": 1})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"bullets_expire_at": bson.M{"$gt": 0, "$lte": nowMs}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var rooms []struct {
+		RoomID string `bson:"room_id"`
+	}
+	if err := cursor.All(ctx, &rooms); err != nil {
+		return nil, err
+	}
+	roomIDs := make([]string, len(rooms))
+	for i, room := range rooms {
+		roomIDs[i] = room.RoomID
+	}
+	return roomIDs, nil
+}
+
+// CountActive counts rooms that are not closed and have at least one seated
+// player, and the players seated in them.
+func (r *RoomRepository) CountActive(ctx context.Context) (rooms, players int64, err error) {
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": bson.M{"$ne": string(entity.RoomStatusClosed)}}}},
+		{{Key: "$project", Value: bson.M{
+			"seated": bson.M{"$size": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{"$players", bson.M{}}}}},
+		}}},
+		{{Key: "$match", Value: bson.M{"seated": bson.M{"$gt": 0}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     nil,
+			"rooms":   bson.M{"$sum": 1},
+			"players": bson.M{"$sum": "$seated"},
+		}}},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var totals []struct {
+		Rooms   int64 `bson:"rooms"`
+		Players int64 `bson:"players"`
+	}
+	if err := cursor.All(ctx, &totals); err != nil {
+		return 0, 0, err
+	}
+	if len(totals) == 0 {
+		return 0, 0, nil
+	}
+	return totals[0].Rooms, totals[0].Players, nil
 }
@@ -0,0 +1,48 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WalletTransferRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWalletTransferRepository(db *mongo.Database) *WalletTransferRepository {
+	return &WalletTransferRepository{
+		collection: db.Collection("wallet_transfers"),
+	}
+}
+
+func (w *WalletTransferRepository) Save(ctx context.Context, transfer *entity.WalletTransfer) error {
+	opts := options.Update().SetUpsert(true)
+	_, err := w.collection.UpdateOne(
+		ctx,
+		bson.M{"tx_id": transfer.TxID},
+		bson.M{"$set": transfer},
+		opts,
+	)
+	return err
+}
+
+func (w *WalletTransferRepository) ListPending(ctx context.Context, updatedBefore int64, limit int) ([]*entity.WalletTransfer, error) {
+	opts := options.Find().SetSort(bson.M{"updated_at": 1}).SetLimit(int64(limit))
+	cursor, err := w.collection.Find(ctx, bson.M{
+		"status":     entity.WalletTransferPending,
+		"updated_at": bson.M{"$lt": updatedBefore},
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := []*entity.WalletTransfer{}
+	if err := cursor.All(ctx, &transfers); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
@@ -0,0 +1,207 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// configLoadTimeout bounds a load. Concurrent callers share one load,
+	// so it runs detached from any one caller's context.
+	configLoadTimeout = 5 * time.Second
+	// configRefreshAt is the fraction of the local TTL after which an entry
+	// is refreshed in the background while still being served.
+	configRefreshAt = 0.8
+	// negativeCacheValue is stored in Redis for games that have no document
+	// of a type. It is not valid JSON, so it cannot clash with a document.
+	negativeCacheValue = "__not_found__"
+)
+
+// configNotFound is the local cache entry for a game with no document.
+type configNotFound struct{}
+
+// CacheStats counts lookups of one config type. A flight shared by
+// concurrent callers counts once.
+type CacheStats struct {
+	LocalHits    int64 `json:"local_hits"`
+	RedisHits    int64 `json:"redis_hits"`
+	NegativeHits int64 `json:"negative_hits"` // lookups answered "not found" from cache
+	Misses       int64 `json:"misses"`        // lookups that went to Mongo
+	StaleServed  int64 `json:"stale_served"`  // last known good copies served after a failed reload
+	Errors       int64 `json:"errors"`
+}
+
+type cacheCounters struct {
+	localHits    atomic.Int64
+	redisHits    atomic.Int64
+	negativeHits atomic.Int64
+	misses       atomic.Int64
+	staleServed  atomic.Int64
+	errors       atomic.Int64
+}
+
+func (c *cacheCounters) snapshot() CacheStats {
+	return CacheStats{
+		LocalHits:    c.localHits.Load(),
+		RedisHits:    c.redisHits.Load(),
+		NegativeHits: c.negativeHits.Load(),
+		Misses:       c.misses.Load(),
+		StaleServed:  c.staleServed.Load(),
+		Errors:       c.errors.Load(),
+	}
+}
+
+// configCache caches the documents of one config type, T, for every game:
+// in the shared local LRU, then Redis, then through load.
+//
+// Local entries are refreshed in the background once they are close to
+// localTTL and reloaded synchronously after it. If Redis fails the document
+// is loaded directly; if that fails too the last known good local copy is
+// served. Games without a document are remembered for negativeTTL.
+type configCache[T any] struct {
+	configType  string
+	redisClient *redis.Client
+	local       *localCache
+	loads       *singleflight.Group
+	ttl         time.Duration
+	localTTL    time.Duration
+	negativeTTL time.Duration
+	load        func(ctx context.Context, gameName string) (*T, error)
+	notFound    func(gameName string) error
+	stats       cacheCounters
+	refreshing  sync.Map // game name -> struct{}, while a background refresh runs
+}
+
+func configCacheKey(configType, gameName string) string {
+	return fmt.Sprintf("game_config:%s:%s", configType, gameName)
+}
+
+func (c *configCache[T]) get(ctx context.Context, gameName string) (*T, error) {
+	key := configCacheKey(c.configType, gameName)
+
+	cached, age, ok := c.local.get(key)
+	if ok {
+		if _, missing := cached.(configNotFound); missing {
+			if age < c.localTTL && age < c.negativeTTL {
+				c.stats.negativeHits.Add(1)
+				return nil, c.notFound(gameName)
+			}
+		} else if age < c.localTTL {
+			c.stats.localHits.Add(1)
+			if age >= time.Duration(float64(c.localTTL)*configRefreshAt) {
+				if _, running := c.refreshing.LoadOrStore(gameName, struct{}{}); !running {
+					go c.refresh(gameName)
+				}
+			}
+			return cached.(*T), nil
+		}
+	}
+
+	value, err := c.fetch(ctx, gameName)
+	if err != nil {
+		// A document that no longer exists must not be served from the
+		// last known good copy.
+		if stale, isValue := cached.(*T); isValue && !errors.Is(err, c.notFound(gameName)) {
+			c.stats.staleServed.Add(1)
+			return stale, nil
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+func (c *configCache[T]) refresh(gameName string) {
+	defer c.refreshing.Delete(gameName)
+	// A failed refresh leaves the current entry in place until it expires.
+	_, _ = c.fetch(context.Background(), gameName)
+}
+
+// fetch reads a document from Redis, falling back to load on a miss or a
+// Redis error, and stores the result locally. Concurrent fetches of the
+// same key share one round trip; each caller stops waiting when its own ctx
+// ends. A result is not cached if the key was invalidated while it loaded,
+// and callers arriving after an invalidation start a new load rather than
+// joining the old one.
+func (c *configCache[T]) fetch(ctx context.Context, gameName string) (*T, error) {
+	key := configCacheKey(c.configType, gameName)
+	gen := c.local.generation(key)
+
+	flight := c.loads.DoChan(fmt.Sprintf("%s#%d", key, gen), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), configLoadTimeout)
+		defer cancel()
+
+		// Try to get from Redis
+		cachedData, err := c.redisClient.Get(ctx, key).Result()
+		if err == nil {
+			if cachedData == negativeCacheValue {
+				c.stats.negativeHits.Add(1)
+				c.rememberMissing(key, gen)
+				return nil, c.notFound(gameName)
+			}
+			value := new(T)
+			if err := json.Unmarshal([]byte(cachedData), value); err == nil {
+				c.stats.redisHits.Add(1)
+				c.local.setIfCurrent(key, value, gen)
+				return value, nil
+			}
+			// A corrupt entry is overwritten by the load below.
+		}
+		redisDown := err != nil && !errors.Is(err, redis.Nil)
+
+		// Redis cache miss or outage, try MongoDB
+		c.stats.misses.Add(1)
+		value, err := c.load(ctx, gameName)
+		if err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				if c.rememberMissing(key, gen) && !redisDown && c.negativeTTL > 0 {
+					_ = c.redisClient.Set(ctx, key, negativeCacheValue, c.negativeTTL).Err()
+				}
+				return nil, c.notFound(gameName)
+			}
+			c.stats.errors.Add(1)
+			return nil, err
+		}
+
+		// Cache the data in Redis, unless it is known to be unreachable or
+		// the document changed while it loaded
+		if c.local.setIfCurrent(key, value, gen) && !redisDown {
+			if data, err := json.Marshal(value); err == nil {
+				_ = c.redisClient.Set(ctx, key, data, c.ttl).Err()
+			}
+		}
+		return value, nil
+	})
+
+	select {
+	case res := <-flight:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*T), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// rememberMissing stores a negative entry locally when negative caching is
+// on, and otherwise drops whatever was cached for key. It reports false,
+// leaving the cache alone, if key has moved on from gen.
+func (c *configCache[T]) rememberMissing(key string, gen uint64) bool {
+	if c.negativeTTL > 0 {
+		return c.local.setIfCurrent(key, configNotFound{}, gen)
+	}
+	if c.local.generation(key) != gen {
+		return false
+	}
+	c.local.remove(key)
+	return true
+}
@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/memory"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port/porttest"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	connectOnce sync.Once
+	client      *redis.Client
+	connectErr  error
+)
+
+// testClient returns a client for the server at REDIS_ADDR. The server is
+// dialled once per run, and every test is skipped if it does not answer.
+// The suites use random keys, so a shared server is fine.
+func testClient(t *testing.T) *redis.Client {
+	t.Helper()
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	connectOnce.Do(func() {
+		client = redis.NewClient(&redis.Options{Addr: addr, DialTimeout: 2 * time.Second})
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		connectErr = client.Ping(ctx).Err()
+	})
+	if connectErr != nil {
+		t.Skipf("Redis not available at %s: %v", addr, connectErr)
+	}
+	return client
+}
+
+func TestRTPRepositoryContract(t *testing.T) {
+	porttest.RTPRepository(t, func(t *testing.T) port.RTPRepository { return NewRTPRepository(testClient(t)) })
+}
+
+func TestShotResultRepositoryContract(t *testing.T) {
+	porttest.ShotResultRepository(t, func(t *testing.T) port.ShotResultRepository { return NewShotResultRepository(testClient(t)) })
+}
+
+func TestGameConfigCacheContract(t *testing.T) {
+	porttest.GameConfigRepository(t, func(t *testing.T, seed []gameBaseModels.ConfigDocument) port.GameConfigRepository {
+		store := memory.NewGameConfigRepository()
+		for _, doc := range seed {
+			if err := store.SaveDocument(context.Background(), doc, 0); err != nil {
+				t.Fatalf("seed %s: %v", doc.Kind(), err)
+			}
+		}
+		return NewGameConfigCacheRepository(testClient(t), store, GameConfigCacheOptions{TTL: time.Minute, NegativeTTL: time.Second})
+	})
+}
@@ -2,8 +2,6 @@ package redis
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"time"
 
@@ -11,229 +9,170 @@ import (
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
 	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
+// configInvalidationChannel carries the cache key of every config document
+// that changed, so each server instance can drop its local copy.
+const configInvalidationChannel = "game_config:invalidate"
+
+// GameConfigCacheOptions tunes GameConfigCacheRepository.
+type GameConfigCacheOptions struct {
+	// TTL is how long documents stay in Redis; TTLByKind overrides it per
+	// config kind.
+	TTL       time.Duration
+	TTLByKind map[string]time.Duration
+	// NegativeTTL is how long a game without a document is remembered; 0
+	// disables negative caching.
+	NegativeTTL time.Duration
+	// LocalSize bounds the in-process LRU (0 disables it) and LocalTTL caps
+	// how long an entry is served from it without being reloaded.
+	LocalSize int
+	LocalTTL  time.Duration
+}
+
+// GameConfigCacheRepository serves game config from a process-local LRU,
+// then Redis, then Mongo. Returned documents are shared between callers and
+// must not be modified.
 type GameConfigCacheRepository struct {
 	redisClient *redis.Client
-	mongoRepo   port.GameConfigRepository
-	cacheTTL    int // Cache TTL in seconds
+	local       *localCache
+
+	bullets   *configCache[gameBaseModels.BulletConfig]
+	configs   *configCache[gameBaseModels.GameConfig]
+	features  *configCache[gameBaseModels.GameFeatures]
+	paths     *configCache[gameBaseModels.GamePaths]
+	rtps      *configCache[gameBaseModels.GameRTP]
+	fishTypes *configCache[gameBaseModels.GameFishTypes]
 }
 
-func NewGameConfigCacheRepository(redisClient *redis.Client, mongoRepo port.GameConfigRepository, cacheTTL int) *GameConfigCacheRepository {
-	return &GameConfigCacheRepository{
+func NewGameConfigCacheRepository(redisClient *redis.Client, mongoRepo port.GameConfigRepository, opts GameConfigCacheOptions) *GameConfigCacheRepository {
+	r := &GameConfigCacheRepository{
 		redisClient: redisClient,
-		mongoRepo:   mongoRepo,
-		cacheTTL:    cacheTTL,
-	}
+		local:       newLocalCache(opts.LocalSize),
+	}
+	loads := &singleflight.Group{}
+
+	r.bullets = newConfigCache(r, loads, opts, gameBaseModels.ConfigKindBullets, mongoRepo.GetBulletConfig, func(gameName string) error {
+		return apperr.New(apperr.Code("BULLET_CONFIG_NOT_FOUND"), fmt.Sprintf("bullet config not found for game: %s", gameName))
+	})
+	r.configs = newConfigCache(r, loads, opts, gameBaseModels.ConfigKindConfigs, mongoRepo.GetGameConfig, func(gameName string) error {
+		return apperr.New(apperr.Code("GAME_CONFIG_NOT_FOUND"), fmt.Sprintf("game config not found for game: %s", gameName))
+	})
+	r.features = newConfigCache(r, loads, opts, gameBaseModels.ConfigKindFeatures, mongoRepo.GetGameFeatures, func(gameName string) error {
+		return apperr.New(apperr.Code("GAME_FEATURES_NOT_FOUND"), fmt.Sprintf("game features not found for game: %s", gameName))
+	})
+	r.paths = newConfigCache(r, loads, opts, gameBaseModels.ConfigKindPaths, mongoRepo.GetGamePaths, func(gameName string) error {
+		return apperr.New(apperr.Code("GAME_PATHS_NOT_FOUND"), fmt.Sprintf("game paths not found for game: %s", gameName))
+	})
+	r.rtps = newConfigCache(r, loads, opts, gameBaseModels.ConfigKindRTPs, mongoRepo.GetGameRTP, func(gameName string) error {
+		return apperr.New(apperr.Code("GAME_RTP_NOT_FOUND"), fmt.Sprintf("game rtp not found for game: %s", gameName))
+	})
+	r.fishTypes = newConfigCache(r, loads, opts, gameBaseModels.ConfigKindFishTypes, mongoRepo.GetGameFishTypes, func(gameName string) error {
+		return apperr.New(apperr.Code("GAME_FISH_TYPES_NOT_FOUND"), fmt.Sprintf("game fish types not found for game: %s", gameName))
+	})
+	return r
 }
 
-// cacheKey generates Redis cache key for a config type
-func (r *GameConfigCacheRepository) cacheKey(configType, gameName string) string {
-	return fmt.Sprintf("game_config:%s:%s", configType, gameName)
+func newConfigCache[T any](
+	r *GameConfigCacheRepository,
+	loads *singleflight.Group,
+	opts GameConfigCacheOptions,
+	configType string,
+	load func(ctx context.Context, gameName string) (*T, error),
+	notFound func(gameName string) error,
+) *configCache[T] {
+	ttl := opts.TTL
+	if kindTTL, ok := opts.TTLByKind[configType]; ok && kindTTL > 0 {
+		ttl = kindTTL
+	}
+	return &configCache[T]{
+		configType:  configType,
+		redisClient: r.redisClient,
+		local:       r.local,
+		loads:       loads,
+		ttl:         ttl,
+		localTTL:    opts.LocalTTL,
+		negativeTTL: opts.NegativeTTL,
+		load:        load,
+		notFound:    notFound,
+	}
 }
 
-func (r *GameConfigCacheRepository) GetBulletConfig(ctx context.Context, gameName string) (*gameBaseModels.BulletConfig, error) {
-	cacheKey := r.cacheKey("bullets", gameName)
-
-	// Try to get from Redis
-	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
-	if err == nil {
-		// Found in Redis, deserialize and return
-		var config gameBaseModels.BulletConfig
-		if err := json.Unmarshal([]byte(cachedData), &config); err != nil {
-			return nil, err
-		}
-		return &config, nil
+// Invalidate deletes the cached copy of a config document and tells every
+// server instance to drop its local copy.
+func (r *GameConfigCacheRepository) Invalidate(ctx context.Context, kind, gameName string) error {
+	key := configCacheKey(kind, gameName)
+	r.local.remove(key)
+	if err := r.redisClient.Del(ctx, key).Err(); err != nil {
+		return err
 	}
+	return r.redisClient.Publish(ctx, configInvalidationChannel, key).Err()
+}
 
-	if !errors.Is(err, redis.Nil) {
-		// Redis error occurred
-		return nil, err
-	}
+// ListenInvalidations drops local entries named on the invalidation channel
+// until ctx is done. Messages published while the subscription is down are
+// lost, so every local entry is marked stale whenever it (re)connects.
+func (r *GameConfigCacheRepository) ListenInvalidations(ctx context.Context) error {
+	pubsub := r.redisClient.Subscribe(ctx, configInvalidationChannel)
+	defer pubsub.Close()
+
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			r.local.markStale()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+			}
+			continue
+		}
 
-	// Redis cache miss, try MongoDB
-	config, err := r.mongoRepo.GetBulletConfig(ctx, gameName)
-	if err != nil {
-		if errors.Is(err, apperr.ErrNotFound) {
-			return nil, apperr.New(apperr.Code("BULLET_CONFIG_NOT_FOUND"), fmt.Sprintf("bullet config not found for game: %s", gameName))
+		switch m := msg.(type) {
+		case *redis.Subscription:
+			r.local.markStale()
+		case *redis.Message:
+			r.local.remove(m.Payload)
 		}
-		return nil, err
 	}
+}
 
-	// Cache the data in Redis
-	if data, err := json.Marshal(config); err == nil {
-		_ = r.redisClient.Set(ctx, cacheKey, data, time.Duration(r.cacheTTL)*time.Second).Err()
+// Stats returns the lookup counters of each config kind.
+func (r *GameConfigCacheRepository) Stats() map[string]CacheStats {
+	return map[string]CacheStats{
+		gameBaseModels.ConfigKindBullets:   r.bullets.stats.snapshot(),
+		gameBaseModels.ConfigKindConfigs:   r.configs.stats.snapshot(),
+		gameBaseModels.ConfigKindFeatures:  r.features.stats.snapshot(),
+		gameBaseModels.ConfigKindPaths:     r.paths.stats.snapshot(),
+		gameBaseModels.ConfigKindRTPs:      r.rtps.stats.snapshot(),
+		gameBaseModels.ConfigKindFishTypes: r.fishTypes.stats.snapshot(),
 	}
+}
 
-	return config, nil
+func (r *GameConfigCacheRepository) GetBulletConfig(ctx context.Context, gameName string) (*gameBaseModels.BulletConfig, error) {
+	return r.bullets.get(ctx, gameName)
 }
 
 func (r *GameConfigCacheRepository) GetGameConfig(ctx context.Context, gameName string) (*gameBaseModels.GameConfig, error) {
-	cacheKey := r.cacheKey("configs", gameName)
-
-	// Try to get from Redis
-	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var config gameBaseModels.GameConfig
-		if err := json.Unmarshal([]byte(cachedData), &config); err != nil {
-			return nil, err
-		}
-		return &config, nil
-	}
-
-	if !errors.Is(err, redis.Nil) {
-		return nil, err
-	}
-
-	// Redis cache miss, try MongoDB
-	config, err := r.mongoRepo.GetGameConfig(ctx, gameName)
-	if err != nil {
-		if errors.Is(err, apperr.ErrNotFound) {
-			return nil, apperr.New(apperr.Code("GAME_CONFIG_NOT_FOUND"), fmt.Sprintf("game config not found for game: %s", gameName))
-		}
-		return nil, err
-	}
-
-	// Cache the data in Redis
-	if data, err := json.Marshal(config); err == nil {
-		_ = r.redisClient.Set(ctx, cacheKey, data, time.Duration(r.cacheTTL)*time.Second).Err()
-	}
-
-	return config, nil
+	return r.configs.get(ctx, gameName)
 }
 
 func (r *GameConfigCacheRepository) GetGameFeatures(ctx context.Context, gameName string) (*gameBaseModels.GameFeatures, error) {
-	cacheKey := r.cacheKey("features", gameName)
-
-	// Try to get from Redis
-	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var features gameBaseModels.GameFeatures
-		if err := json.Unmarshal([]byte(cachedData), &features); err != nil {
-			return nil, err
-		}
-		return &features, nil
-	}
-
-	if !errors.Is(err, redis.Nil) {
-		return nil, err
-	}
-
-	// Redis cache miss, try MongoDB
-	features, err := r.mongoRepo.GetGameFeatures(ctx, gameName)
-	if err != nil {
-		if errors.Is(err, apperr.ErrNotFound) {
-			return nil, apperr.New(apperr.Code("GAME_FEATURES_NOT_FOUND"), fmt.Sprintf("game features not found for game: %s", gameName))
-		}
-		return nil, err
-	}
-
-	// Cache the data in Redis
-	if data, err := json.Marshal(features); err == nil {
-		_ = r.redisClient.Set(ctx, cacheKey, data, time.Duration(r.cacheTTL)*time.Second).Err()
-	}
-
-	return features, nil
+	return r.features.get(ctx, gameName)
 }
 
 func (r *GameConfigCacheRepository) GetGamePaths(ctx context.Context, gameName string) (*gameBaseModels.GamePaths, error) {
-	cacheKey := r.cacheKey("paths", gameName)
-
-	// Try to get from Redis
-	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var paths gameBaseModels.GamePaths
-		if err := json.Unmarshal([]byte(cachedData), &paths); err != nil {
-			return nil, err
-		}
-		return &paths, nil
-	}
-
-	if !errors.Is(err, redis.Nil) {
-		return nil, err
-	}
-
-	// Redis cache miss, try MongoDB
-	paths, err := r.mongoRepo.GetGamePaths(ctx, gameName)
-	if err != nil {
-		if errors.Is(err, apperr.ErrNotFound) {
-			return nil, apperr.New(apperr.Code("GAME_PATHS_NOT_FOUND"), fmt.Sprintf("game paths not found for game: %s", gameName))
-		}
-		return nil, err
-	}
-
-	// Cache the data in Redis
-	if data, err := json.Marshal(paths); err == nil {
-		_ = r.redisClient.Set(ctx, cacheKey, data, time.Duration(r.cacheTTL)*time.Second).Err()
-	}
-
-	return paths, nil
+	return r.paths.get(ctx, gameName)
 }
 
 func (r *GameConfigCacheRepository) GetGameRTP(ctx context.Context, gameName string) (*gameBaseModels.GameRTP, error) {
-	cacheKey := r.cacheKey("rtps", gameName)
-
-	// Try to get from Redis
-	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var rtp gameBaseModels.GameRTP
-		if err := json.Unmarshal([]byte(cachedData), &rtp); err != nil {
-			return nil, err
-		}
-		return &rtp, nil
-	}
-
-	if !errors.Is(err, redis.Nil) {
-		return nil, err
-	}
-
-	// Redis cache miss, try MongoDB
-	rtp, err := r.mongoRepo.GetGameRTP(ctx, gameName)
-	if err != nil {
-		if errors.Is(err, apperr.ErrNotFound) {
-			return nil, apperr.New(apperr.Code("GAME_RTP_NOT_FOUND"), fmt.Sprintf("game rtp not found for game: %s", gameName))
-		}
-		return nil, err
-	}
-
-	// Cache the data in Redis
-	if data, err := json.Marshal(rtp); err == nil {
-		_ = r.redisClient.Set(ctx, cacheKey, data, time.Duration(r.cacheTTL)*time.Second).Err()
-	}
-
-	return rtp, nil
+	return r.rtps.get(ctx, gameName)
 }
 
 func (r *GameConfigCacheRepository) GetGameFishTypes(ctx context.Context, gameName string) (*gameBaseModels.GameFishTypes, error) {
-	cacheKey := r.cacheKey("types", gameName)
-
-	// Try to get from Redis
-	cachedData, err := r.redisClient.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var fishTypes gameBaseModels.GameFishTypes
-		if err := json.Unmarshal([]byte(cachedData), &fishTypes); err != nil {
-			return nil, err
-		}
-		return &fishTypes, nil
-	}
-
-	if !errors.Is(err, redis.Nil) {
-		return nil, err
-	}
-
-	// Redis cache miss, try MongoDB
-	fishTypes, err := r.mongoRepo.GetGameFishTypes(ctx, gameName)
-	if err != nil {
-		if errors.Is(err, apperr.ErrNotFound) {
-			return nil, apperr.New(apperr.Code("GAME_FISH_TYPES_NOT_FOUND"), fmt.Sprintf("game fish types not found for game: %s", gameName))
-		}
-		return nil, err
-	}
-
-	// Cache the data in Redis
-	if data, err := json.Marshal(fishTypes); err == nil {
-		_ = r.redisClient.Set(ctx, cacheKey, data, time.Duration(r.cacheTTL)*time.Second).Err()
-	}
-
-	return fishTypes, nil
+	return r.fishTypes.get(ctx, gameName)
 }
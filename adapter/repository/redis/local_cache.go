@@ -0,0 +1,125 @@
+package redis
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// localCache is a size-bounded, in-process LRU of decoded config values.
+// Entries are kept past their freshness so they can serve as the last known
+// good copy; callers decide from the stored time whether to use them.
+//
+// Every key has a generation that moves on whenever its entry is removed or
+// marked stale. A load records the generation before it starts and stores
+// its result only if it has not moved, so a load that raced an
+// invalidation cannot put the old value back.
+type localCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+	gens    map[string]uint64
+	epoch   uint64 // bumped by markStale, moving every key's generation
+	now     func() time.Time
+}
+
+type localEntry struct {
+	key      string
+	value    interface{}
+	storedAt time.Time
+}
+
+func newLocalCache(size int) *localCache {
+	return &localCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+		gens:    map[string]uint64{},
+		now:     time.Now,
+	}
+}
+
+// get returns the value stored under key and how long ago it was stored.
+func (c *localCache) get(key string) (interface{}, time.Duration, bool) {
+	if c.size <= 0 {
+		return nil, 0, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, 0, false
+	}
+	entry := el.Value.(*localEntry)
+	c.order.MoveToFront(el)
+	return entry.value, c.now().Sub(entry.storedAt), true
+}
+
+// generation returns the current generation of key.
+func (c *localCache) generation(key string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.epoch + c.gens[key]
+}
+
+// setIfCurrent stores value under key if the key is still at gen, and
+// reports whether it was.
+func (c *localCache) setIfCurrent(key string, value interface{}, gen uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.epoch+c.gens[key] != gen {
+		return false
+	}
+	c.set(key, value)
+	return true
+}
+
+// set stores value under key. c.mu must be held.
+func (c *localCache) set(key string, value interface{}) {
+	if c.size <= 0 {
+		return
+	}
+
+	storedAt := c.now()
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*localEntry)
+		entry.value = value
+		entry.storedAt = storedAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&localEntry{key: key, value: value, storedAt: storedAt})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*localEntry).key)
+	}
+}
+
+func (c *localCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gens[key]++
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// markStale makes every entry due for a reload while keeping it as the
+// last known good copy.
+func (c *localCache) markStale() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		el.Value.(*localEntry).storedAt = time.Time{}
+	}
+}
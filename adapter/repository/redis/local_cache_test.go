@@ -0,0 +1,28 @@
+package redis
+
+import "testing"
+
+func TestLocalCacheDropsResultsLoadedBeforeAnInvalidation(t *testing.T) {
+	c := newLocalCache(8)
+	const key = "game_config:rtps:reef"
+
+	gen := c.generation(key)
+	c.remove(key)
+	if c.setIfCurrent(key, "v1", gen) {
+		t.Fatal("stored a value loaded before the key was removed")
+	}
+
+	gen = c.generation(key)
+	c.markStale()
+	if c.setIfCurrent(key, "v1", gen) {
+		t.Fatal("stored a value loaded before the cache was marked stale")
+	}
+
+	gen = c.generation(key)
+	if !c.setIfCurrent(key, "v2", gen) {
+		t.Fatal("refused a value loaded at the current generation")
+	}
+	if value, _, ok := c.get(key); !ok || value != "v2" {
+		t.Fatalf("get = %v, %v; want v2", value, ok)
+	}
+}
@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+const shotPendingMarker = "pending"
+
+// releaseScript deletes a claim only while it is still pending, so a late
+// Release cannot wipe a completed result.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type ShotResultRepository struct {
+	client *redis.Client
+}
+
+func NewShotResultRepository(client *redis.Client) *ShotResultRepository {
+	return &ShotResultRepository{
+		client: client,
+	}
+}
+
+func (r *ShotResultRepository) key(playerID, bulletID string) string {
+	return fmt.Sprintf("shot:%s:%s", playerID, bulletID)
+}
+
+func (r *ShotResultRepository) Claim(ctx context.Context, playerID, bulletID string, ttl time.Duration) (bool, *entity.ShotResult, error) {
+	key := r.key(playerID, bulletID)
+	claimed, err := r.client.SetNX(ctx, key, shotPendingMarker, ttl).Result()
+	if err != nil {
+		return false, nil, err
+	}
+	if claimed {
+		return true, nil, nil
+	}
+
+	val, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			// Expired between SETNX and GET; treat as still in flight.
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	if val == shotPendingMarker {
+		return false, nil, nil
+	}
+
+	var result entity.ShotResult
+	if err := json.Unmarshal([]byte(val), &result); err != nil {
+		return false, nil, err
+	}
+	return false, &result, nil
+}
+
+func (r *ShotResultRepository) Complete(ctx context.Context, playerID, bulletID string, result *entity.ShotResult, ttl time.Duration) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.key(playerID, bulletID), string(data), ttl).Err()
+}
+
+func (r *ShotResultRepository) Release(ctx context.Context, playerID, bulletID string) error {
+	return releaseScript.Run(ctx, r.client, []string{r.key(playerID, bulletID)}, shotPendingMarker).Err()
+}
@@ -0,0 +1,28 @@
+package rng
+
+import (
+	"crypto/rand"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"go.uber.org/zap"
+)
+
+const seedSize = 32
+
+// NewCryptoRNG seeds each room stream with 32 bytes from crypto/rand. This is
+// the production RNG.
+func NewCryptoRNG(logger *zap.Logger) port.RNG {
+	return &roomStreams{
+		streams: map[string]*stream{},
+		newSeed: func(string) []byte {
+			seed := make([]byte, seedSize)
+			if _, err := rand.Read(seed); err != nil {
+				// The OS entropy source failing leaves no safe fallback.
+				panic("rng: crypto/rand unavailable: " + err.Error())
+			}
+			return seed
+		},
+		source: "crypto",
+		logger: logger,
+	}
+}
@@ -0,0 +1,29 @@
+package rng
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"go.uber.org/zap"
+)
+
+// NewSeededRNG derives each room seed from a master seed and the room id, so
+// the same master seed always produces the same draws for the same room. A
+// room released and played again gets the same seed, so its draws repeat.
+// Use it for tests and simulation only.
+func NewSeededRNG(masterSeed int64, logger *zap.Logger) port.RNG {
+	var master [8]byte
+	binary.BigEndian.PutUint64(master[:], uint64(masterSeed))
+	return &roomStreams{
+		streams: map[string]*stream{},
+		newSeed: func(roomID string) []byte {
+			mac := hmac.New(sha256.New, master[:])
+			mac.Write([]byte(roomID))
+			return mac.Sum(nil)
+		},
+		source: "seeded",
+		logger: logger,
+	}
+}
@@ -0,0 +1,116 @@
+package rng
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"go.uber.org/zap"
+)
+
+// Value derives draw index of the stream keyed by seed:
+// HMAC-SHA256(seed, big-endian index), with the top 53 bits of the digest
+// scaled into [0, 1). Auditors can recompute any logged outcome with it.
+func Value(seed []byte, index uint64) float64 {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], index)
+	mac := hmac.New(sha256.New, seed)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	return float64(binary.BigEndian.Uint64(sum[:8])>>11) / (1 << 53)
+}
+
+// SeedID is the public name of a seed: the first 8 bytes of its SHA-256,
+// hex encoded. It is stored with outcomes in place of the seed itself.
+func SeedID(seed []byte) string {
+	sum := sha256.Sum256(seed)
+	return hex.EncodeToString(sum[:8])
+}
+
+type stream struct {
+	mu     sync.Mutex
+	seed   []byte
+	seedID string
+	next   uint64
+}
+
+func newStream(seed []byte) *stream {
+	return &stream{seed: seed, seedID: SeedID(seed)}
+}
+
+func (s *stream) SeedID() string {
+	return s.seedID
+}
+
+func (s *stream) Draw() (float64, uint64) {
+	s.mu.Lock()
+	index := s.next
+	s.next++
+	s.mu.Unlock()
+	return Value(s.seed, index), index
+}
+
+// roomStreams keeps one stream per room. The seed is logged when the stream
+// is seeded, so the audit record needed to reproduce outcomes exists before
+// the first draw and survives a crash; logs must be kept as confidential as
+// the seeds. Retiring a stream logs how many draws it served.
+type roomStreams struct {
+	mu      sync.Mutex
+	streams map[string]*stream
+	newSeed func(roomID string) []byte
+	source  string
+	logger  *zap.Logger
+}
+
+func (r *roomStreams) Stream(roomID string) port.RandomStream {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.streams[roomID]; ok {
+		return s
+	}
+
+	s := newStream(r.newSeed(roomID))
+	r.streams[roomID] = s
+	r.logger.Info("Seeded room RNG stream",
+		zap.String("room_id", roomID),
+		zap.String("rng", r.source),
+		zap.String("seed_id", s.seedID),
+		zap.String("seed", hex.EncodeToString(s.seed)),
+	)
+	return s
+}
+
+func (r *roomStreams) ReleaseRoom(roomID string) {
+	r.mu.Lock()
+	s, ok := r.streams[roomID]
+	delete(r.streams, roomID)
+	r.mu.Unlock()
+	if ok {
+		r.retire(roomID, s)
+	}
+}
+
+func (r *roomStreams) ReleaseAll() {
+	r.mu.Lock()
+	streams := r.streams
+	r.streams = map[string]*stream{}
+	r.mu.Unlock()
+	for roomID, s := range streams {
+		r.retire(roomID, s)
+	}
+}
+
+func (r *roomStreams) retire(roomID string, s *stream) {
+	s.mu.Lock()
+	draws := s.next
+	s.mu.Unlock()
+	r.logger.Info("Retired room RNG stream",
+		zap.String("room_id", roomID),
+		zap.String("rng", r.source),
+		zap.String("seed_id", s.seedID),
+		zap.Uint64("draws", draws),
+	)
+}
@@ -0,0 +1,53 @@
+package rng
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSeedIsLoggedWhenTheStreamIsSeeded(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	r := NewCryptoRNG(zap.New(core))
+
+	first := r.Stream("room-1")
+	first.Draw()
+	seeded := logs.FilterMessage("Seeded room RNG stream").All()
+	if len(seeded) != 1 {
+		t.Fatalf("seeding logged %d times, want once", len(seeded))
+	}
+	if fields := seeded[0].ContextMap(); fields["seed_id"] != first.SeedID() || fields["seed"] == "" {
+		t.Fatalf("seeding fields = %v", fields)
+	}
+
+	r.ReleaseRoom("room-1")
+	retired := logs.FilterMessage("Retired room RNG stream").All()
+	if len(retired) != 1 {
+		t.Fatalf("retirement logged %d times, want once", len(retired))
+	}
+	if fields := retired[0].ContextMap(); fields["seed_id"] != first.SeedID() || fields["draws"] != uint64(1) {
+		t.Fatalf("retirement fields = %v", fields)
+	}
+
+	if next := r.Stream("room-1"); next.SeedID() == first.SeedID() {
+		t.Fatal("a released room kept its seed")
+	}
+}
+
+func TestReleaseAllRetiresEveryStream(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	r := NewSeededRNG(1, zap.New(core))
+
+	r.Stream("room-1").Draw()
+	r.Stream("room-2")
+	r.ReleaseAll()
+	if n := logs.FilterMessage("Retired room RNG stream").Len(); n != 2 {
+		t.Fatalf("ReleaseAll retired %d streams, want 2", n)
+	}
+
+	r.ReleaseAll()
+	if n := logs.FilterMessage("Retired room RNG stream").Len(); n != 2 {
+		t.Fatalf("a second ReleaseAll retired %d streams in all, want 2", n)
+	}
+}
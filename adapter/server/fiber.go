@@ -1,30 +1,42 @@
 package server
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/contract"
 	"github.com/gofiber/fiber/v2"
 )
 
 // FiberServer wraps *fiber.App to implement contract.Server interface
 type FiberServer struct {
-	app *fiber.App
+	app    *fiber.App
+	addr   string
+	logger contract.Logger
 }
 
-// NewFiberServer creates a new Fiber server adapter
-func NewFiberServer(app *fiber.App) contract.Server {
+// NewFiberServer creates a new Fiber server adapter listening on host:port
+func NewFiberServer(app *fiber.App, host string, port int, logger contract.Logger) contract.Server {
 	return &FiberServer{
-		app: app,
+		app:    app,
+		addr:   fmt.Sprintf("%s:%d", host, port),
+		logger: logger,
 	}
 }
 
 func (f *FiberServer) Start() error {
-	return f.app.Listen(":8080")
+	f.logger.Info("Starting server", "addr", f.addr)
+	return f.app.Listen(f.addr)
 }
 
 func (f *FiberServer) Stop() error {
 	return f.app.Shutdown()
 }
 
+func (f *FiberServer) Shutdown(ctx context.Context) error {
+	return f.app.ShutdownWithContext(ctx)
+}
+
 func (f *FiberServer) GetNative() interface{} {
 	return f.app
 }
@@ -38,8 +50,5 @@ func NewFiberServerFactory() contract.ServerFactory {
 }
 
 func (f *FiberServerFactory) CreateServer(host string, port int, logger contract.Logger) (contract.Server, error) {
-	// Create a basic Fiber app
-	// Note: This is a basic implementation. In real usage, you might want to configure it further
-	app := fiber.New()
-	return NewFiberServer(app), nil
+	return NewFiberServer(fiber.New(), host, port, logger), nil
 }
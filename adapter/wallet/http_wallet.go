@@ -0,0 +1,129 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+// HTTPWalletProvider talks to the operator platform's seamless wallet API.
+// Every call is a JSON POST to {baseURL}/{operation}; the platform is
+// expected to deduplicate debits, credits and rollbacks by tx_id.
+type HTTPWalletProvider struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+type walletRequest struct {
+	PlayerID string `json:"player_id"`
+	TxID     string `json:"tx_id,omitempty"`
+	Amount   int64  `json:"amount,omitempty"`
+}
+
+type walletResponse struct {
+	Balance   int64  `json:"balance"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+func NewHTTPWalletProvider(baseURL, apiKey string, timeoutMs int) port.WalletProvider {
+	return &HTTPWalletProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: time.Duration(timeoutMs) * time.Millisecond},
+	}
+}
+
+func (w *HTTPWalletProvider) GetBalance(ctx context.Context, playerID string) (int64, error) {
+	resp, err := w.call(ctx, "balance", walletRequest{PlayerID: playerID})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Balance, nil
+}
+
+func (w *HTTPWalletProvider) Debit(ctx context.Context, playerID, txID string, amount int64) (int64, error) {
+	resp, err := w.call(ctx, "debit", walletRequest{PlayerID: playerID, TxID: txID, Amount: amount})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Balance, nil
+}
+
+func (w *HTTPWalletProvider) Credit(ctx context.Context, playerID, txID string, amount int64) (int64, error) {
+	resp, err := w.call(ctx, "credit", walletRequest{PlayerID: playerID, TxID: txID, Amount: amount})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Balance, nil
+}
+
+func (w *HTTPWalletProvider) Rollback(ctx context.Context, playerID, txID string) error {
+	_, err := w.call(ctx, "rollback", walletRequest{PlayerID: playerID, TxID: txID})
+	return err
+}
+
+func (w *HTTPWalletProvider) call(ctx context.Context, operation string, body walletRequest) (*walletResponse, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.baseURL+"/"+operation, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.apiKey != "" {
+		req.Header.Set("X-Api-Key", w.apiKey)
+	}
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("wallet %s: %w", operation, err)
+	}
+	defer res.Body.Close()
+
+	var resp walletResponse
+	if err := json.NewDecoder(res.Body).Decode(&resp); err != nil && res.StatusCode < 500 {
+		return nil, fmt.Errorf("wallet %s: decode response: %w", operation, err)
+	}
+
+	if res.StatusCode >= 500 {
+		return nil, fmt.Errorf("wallet %s: status %d", operation, res.StatusCode)
+	}
+	if res.StatusCode >= 400 || resp.ErrorCode != "" {
+		return nil, mapWalletError(resp.ErrorCode, resp.Message)
+	}
+	return &resp, nil
+}
+
+// mapWalletError translates platform error codes into definitive apperr
+// errors; callers never retry these.
+func mapWalletError(code, message string) error {
+	switch code {
+	case "INSUFFICIENT_FUNDS":
+		return apperr.ErrInsufficientBalance
+	case "PLAYER_NOT_FOUND":
+		return apperr.ErrPlayerNotFound
+	case "TX_NOT_FOUND":
+		return apperr.ErrWalletTxNotFound
+	case "TX_ROLLED_BACK":
+		return apperr.ErrWalletTxRolledBack
+	}
+	if code == "" {
+		code = "REJECTED"
+	}
+	if message == "" {
+		message = "wallet rejected request"
+	}
+	return apperr.New(apperr.Code("WALLET_"+code), message)
+}
@@ -0,0 +1,106 @@
+package wallet
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type memoryTx struct {
+	playerID   string
+	delta      int64
+	rolledBack bool
+}
+
+// MemoryWalletProvider is an in-process wallet for local development. Unknown
+// players start with initialBalance so the game can be played without an
+// operator platform.
+type MemoryWalletProvider struct {
+	mu             sync.Mutex
+	initialBalance int64
+	balances       map[string]int64
+	txs            map[string]*memoryTx
+}
+
+func NewMemoryWalletProvider(initialBalance int64) port.WalletProvider {
+	return &MemoryWalletProvider{
+		initialBalance: initialBalance,
+		balances:       map[string]int64{},
+		txs:            map[string]*memoryTx{},
+	}
+}
+
+func (w *MemoryWalletProvider) GetBalance(ctx context.Context, playerID string) (int64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.balance(playerID), nil
+}
+
+func (w *MemoryWalletProvider) Debit(ctx context.Context, playerID, txID string, amount int64) (int64, error) {
+	if amount < 0 {
+		return 0, apperr.ErrInvalidBalance
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if tx, done := w.txs[txID]; done {
+		if tx.rolledBack {
+			return 0, apperr.ErrWalletTxRolledBack
+		}
+		return w.balance(playerID), nil
+	}
+	if w.balance(playerID) < amount {
+		return 0, apperr.ErrInsufficientBalance
+	}
+	w.balances[playerID] -= amount
+	w.txs[txID] = &memoryTx{playerID: playerID, delta: -amount}
+	return w.balances[playerID], nil
+}
+
+func (w *MemoryWalletProvider) Credit(ctx context.Context, playerID, txID string, amount int64) (int64, error) {
+	if amount < 0 {
+		return 0, apperr.ErrInvalidBalance
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if tx, done := w.txs[txID]; done {
+		if tx.rolledBack {
+			return 0, apperr.ErrWalletTxRolledBack
+		}
+		return w.balance(playerID), nil
+	}
+	w.balances[playerID] = w.balance(playerID) + amount
+	w.txs[txID] = &memoryTx{playerID: playerID, delta: amount}
+	return w.balances[playerID], nil
+}
+
+// Rollback reverses a transaction. Rolling back an unknown transaction
+// records a tombstone, so a late Debit or Credit with the same id fails with
+// apperr.ErrWalletTxRolledBack instead of moving funds.
+func (w *MemoryWalletProvider) Rollback(ctx context.Context, playerID, txID string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	tx, ok := w.txs[txID]
+	if !ok {
+		w.txs[txID] = &memoryTx{playerID: playerID, rolledBack: true}
+		return nil
+	}
+	if tx.rolledBack {
+		return nil
+	}
+	w.balances[tx.playerID] = w.balance(tx.playerID) - tx.delta
+	tx.rolledBack = true
+	return nil
+}
+
+// balance must be called with w.mu held.
+func (w *MemoryWalletProvider) balance(playerID string) int64 {
+	if b, ok := w.balances[playerID]; ok {
+		return b
+	}
+	w.balances[playerID] = w.initialBalance
+	return w.initialBalance
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/ws"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"github.com/fasthttp/websocket"
+)
+
+const (
+	defaultFireRate = 250 * time.Millisecond
+	spawnInterval   = time.Second
+	leaveTimeout    = 5 * time.Second
+)
+
+// settings are the knobs shared by every bot in a run.
+type settings struct {
+	gameName     string
+	roomSize     int
+	buyIn        int64
+	guns         []int
+	fish         []int
+	fishAlive    int
+	fishLife     time.Duration
+	gunChange    time.Duration
+	resync       time.Duration
+	flight       time.Duration
+	fireInterval time.Duration
+}
+
+// room is shared by the bots seated in it. The host creates it and closes
+// ready; the others wait for that before joining. open is set before ready
+// is closed and tells them whether the room could be created.
+type room struct {
+	id    string
+	ready chan struct{}
+	open  bool
+}
+
+// bot is one virtual player. The host of a room also keeps it stocked with
+// fish, which needs the operator role. rng is only used by the goroutine
+// running run.
+type bot struct {
+	*client
+	playerID string
+	seatID   int
+	host     bool
+	room     *room
+	cfg      *settings
+	rng      *rand.Rand
+
+	mu         sync.Mutex
+	fish       []entity.FishSnapshot
+	fireRate   time.Duration
+	resyncSent time.Time
+	// viewAt is when the snapshot behind fish was requested.
+	viewAt time.Time
+
+	shots   int
+	pending sync.WaitGroup
+}
+
+func (b *bot) run(ctx context.Context) {
+	defer b.pending.Wait()
+
+	if b.host {
+		err := b.createRoom(ctx, b.room.id, b.cfg.gameName, b.cfg.roomSize)
+		// The room surviving from an earlier run with the same id is fine.
+		b.room.open = err == nil || hasCode(err, string(apperr.CodeRoomAlreadyExists))
+		close(b.room.ready)
+	} else {
+		select {
+		case <-b.room.ready:
+		case <-ctx.Done():
+			return
+		}
+	}
+	if !b.room.open {
+		return
+	}
+
+	if !b.enter(ctx) {
+		return
+	}
+	defer func() {
+		leaveCtx, cancel := context.WithTimeout(context.Background(), leaveTimeout)
+		defer cancel()
+		_ = b.leave(leaveCtx, b.room.id)
+	}()
+
+	conn, err := b.dialRoom(ctx, b.room.id)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	go b.readSnapshots(conn)
+	go b.resyncLoop(ctx, conn)
+	if b.host {
+		go b.stockFish(ctx, rand.New(rand.NewSource(b.rng.Int63())))
+	}
+
+	b.fireLoop(ctx)
+}
+
+// enter joins the room and picks a random gun.
+func (b *bot) enter(ctx context.Context) bool {
+	if err := b.join(ctx, b.room.id, b.seatID, b.cfg.buyIn); err != nil {
+		return false
+	}
+	b.switchGun(ctx)
+	return true
+}
+
+func (b *bot) switchGun(ctx context.Context) {
+	if len(b.cfg.guns) == 0 {
+		return
+	}
+	_ = b.changeGun(ctx, b.room.id, b.cfg.guns[b.rng.Intn(len(b.cfg.guns))])
+}
+
+func (b *bot) fireLoop(ctx context.Context) {
+	gunChange := newTicker(b.cfg.gunChange)
+	defer gunChange.Stop()
+
+	timer := time.NewTimer(b.nextShot())
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-gunChange.C:
+			b.switchGun(ctx)
+		case <-timer.C:
+			if !b.shoot(ctx) {
+				return
+			}
+			timer.Reset(b.nextShot())
+		}
+	}
+}
+
+// shoot fires at a random live fish and resolves the hit once the bullet
+// has had time to fly. It returns false when the bot has to stop.
+func (b *bot) shoot(ctx context.Context) bool {
+	target, ok := b.target()
+	if !ok {
+		return true
+	}
+	b.shots++
+	bulletID := fmt.Sprintf("%s-%d", b.playerID, b.shots)
+
+	fired, err := b.fire(ctx, b.room.id, bulletID)
+	switch {
+	case hasCode(err, string(apperr.CodeInsufficientBalance)):
+		// Broke: cash out what is left and buy in again.
+		_ = b.leave(ctx, b.room.id)
+		return b.enter(ctx)
+	case err != nil:
+		return true
+	}
+	b.stats.shot(fired.Cost, 0)
+
+	b.pending.Add(1)
+	go func() {
+		defer b.pending.Done()
+		select {
+		case <-time.After(b.cfg.flight):
+		case <-ctx.Done():
+			return
+		}
+		if res, err := b.hit(ctx, b.room.id, bulletID, target); err == nil {
+			b.stats.shot(0, res.Reward)
+		}
+	}()
+	return true
+}
+
+func (b *bot) target() (string, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.fish) == 0 {
+		return "", false
+	}
+	return b.fish[b.rng.Intn(len(b.fish))].FishUID, true
+}
+
+// nextShot waits the gun's fire rate, give or take 20%, like a player
+// holding the trigger.
+func (b *bot) nextShot() time.Duration {
+	rate := b.cfg.fireInterval
+	if rate <= 0 {
+		b.mu.Lock()
+		rate = b.fireRate
+		b.mu.Unlock()
+	}
+	if rate <= 0 {
+		rate = defaultFireRate
+	}
+	return time.Duration(float64(rate) * (0.8 + 0.4*b.rng.Float64()))
+}
+
+// resyncLoop asks for a snapshot every cfg.resync; readSnapshots times the
+// reply.
+func (b *bot) resyncLoop(ctx context.Context, conn *websocket.Conn) {
+	ticker := newTicker(b.cfg.resync)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+			return
+		case <-ticker.C:
+			b.mu.Lock()
+			b.resyncSent = time.Now()
+			b.mu.Unlock()
+			if err := conn.WriteJSON(ws.Message{Type: ws.MessageTypeResync}); err != nil {
+				b.stats.record("ws_resync", 0, err)
+				return
+			}
+		}
+	}
+}
+
+func (b *bot) readSnapshots(conn *websocket.Conn) {
+	for {
+		var msg struct {
+			ws.Message
+			Data json.RawMessage `json:"data"`
+		}
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		b.mu.Lock()
+		sent := b.resyncSent
+		b.resyncSent = time.Time{}
+		b.mu.Unlock()
+
+		switch msg.Type {
+		case ws.MessageTypeSnapshot:
+			var snapshot entity.RoomSnapshot
+			if err := json.Unmarshal(msg.Data, &snapshot); err != nil {
+				b.stats.recordCode("ws_resync", "BAD_SNAPSHOT")
+				continue
+			}
+			requested := sent
+			if requested.IsZero() {
+				requested = time.Now()
+			}
+			b.apply(&snapshot, requested)
+			if !sent.IsZero() {
+				b.stats.record("ws_resync", time.Since(sent), nil)
+			}
+		case ws.MessageTypeError:
+			code := msg.Code
+			if code == "" {
+				code = "WS_ERROR"
+			}
+			b.stats.recordCode("ws_resync", code)
+		}
+	}
+}
+
+func (b *bot) apply(snapshot *entity.RoomSnapshot, requested time.Time) {
+	fish := make([]entity.FishSnapshot, 0, len(snapshot.Fish))
+	for _, f := range snapshot.Fish {
+		if f.Alive {
+			fish = append(fish, f)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fish = fish
+	b.viewAt = requested
+	for _, seat := range snapshot.Seats {
+		if seat.PlayerID == b.playerID && seat.Gun != nil {
+			b.fireRate = time.Duration(seat.Gun.FireRateMs) * time.Millisecond
+		}
+	}
+}
+
+// stockFish keeps cfg.fishAlive fish in the room, letting each escape once
+// it has been alive for cfg.fishLife, the way a spawn server would. It
+// tracks its own spawns, since snapshots lag behind them. It draws from its
+// own rng, as the fire loop runs alongside it.
+func (b *bot) stockFish(ctx context.Context, rng *rand.Rand) {
+	ticker := time.NewTicker(spawnInterval)
+	defer ticker.Stop()
+	live := map[string]time.Time{}
+	spawned := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		b.mu.Lock()
+		fish, viewAt := b.fish, b.viewAt
+		b.mu.Unlock()
+		seen := make(map[string]bool, len(fish))
+		for _, f := range fish {
+			seen[f.FishUID] = true
+		}
+
+		for uid, at := range live {
+			switch {
+			case at.Before(viewAt) && !seen[uid]:
+				// Killed since the last snapshot.
+				delete(live, uid)
+			case time.Since(at) >= b.cfg.fishLife:
+				_ = b.escape(ctx, b.room.id, uid)
+				delete(live, uid)
+			}
+		}
+		for len(live) < b.cfg.fishAlive && len(b.cfg.fish) > 0 {
+			spawned++
+			uid := fmt.Sprintf("%s-f%d", b.room.id, spawned)
+			fishID := b.cfg.fish[rng.Intn(len(b.cfg.fish))]
+			if err := b.spawn(ctx, b.room.id, fishID, uid, rng.Intn(8)+1); err != nil {
+				break
+			}
+			live[uid] = time.Now()
+		}
+	}
+}
+
+// newTicker returns a ticker that never fires when d is not positive.
+func newTicker(d time.Duration) *time.Ticker {
+	if d <= 0 {
+		t := time.NewTicker(time.Hour)
+		t.Stop()
+		return t
+	}
+	return time.NewTicker(d)
+}
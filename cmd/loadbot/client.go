@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/fasthttp/websocket"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// apiError is a non-2xx response. Code is the apperr code from the body.
+type apiError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Code, e.Message)
+}
+
+// errorCode names an error for the report: the apperr code when the server
+// sent one, otherwise the HTTP status or the kind of transport failure.
+func errorCode(err error) string {
+	var apiErr *apiError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Code != "":
+		return apiErr.Code
+	case errors.As(err, &apiErr):
+		return fmt.Sprintf("HTTP_%d", apiErr.Status)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "TIMEOUT"
+	default:
+		return "TRANSPORT"
+	}
+}
+
+func hasCode(err error, code string) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// client calls the game API as one player, timing every call.
+type client struct {
+	base  string
+	http  *http.Client
+	token string
+	stats *recorder
+}
+
+func signToken(secret []byte, playerID string, roles []string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := &middleware.Claims{
+		PlayerID: playerID,
+		Roles:    roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+func (c *client) do(ctx context.Context, op, method, path string, body, out interface{}) error {
+	start := time.Now()
+	err := c.roundTrip(ctx, method, path, body, out)
+	c.stats.record(op, time.Since(start), err)
+	return err
+}
+
+func (c *client) roundTrip(ctx context.Context, method, path string, body, out interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		var e struct {
+			Error string `json:"error"`
+			Code  string `json:"code"`
+		}
+		_ = json.Unmarshal(data, &e)
+		return &apiError{Status: resp.StatusCode, Code: e.Code, Message: e.Error}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (c *client) createRoom(ctx context.Context, roomID, gameName string, maxPlayers int) error {
+	body := map[string]interface{}{"room_id": roomID, "game_name": gameName, "max_players": maxPlayers}
+	return c.do(ctx, "create_room", http.MethodPost, "/api/v1/rooms", body, nil)
+}
+
+func (c *client) join(ctx context.Context, roomID string, seatID int, buyIn int64) error {
+	body := map[string]interface{}{"seat_id": seatID, "buy_in": buyIn}
+	return c.do(ctx, "join", http.MethodPost, "/api/v1/rooms/"+url.PathEscape(roomID)+"/join", body, nil)
+}
+
+func (c *client) leave(ctx context.Context, roomID string) error {
+	return c.do(ctx, "leave", http.MethodPost, "/api/v1/rooms/"+url.PathEscape(roomID)+"/leave", nil, nil)
+}
+
+func (c *client) changeGun(ctx context.Context, roomID string, gunID int) error {
+	body := map[string]interface{}{"room_id": roomID, "gun_id": gunID}
+	return c.do(ctx, "change_gun", http.MethodPost, "/api/v1/shoot/gun", body, nil)
+}
+
+func (c *client) fire(ctx context.Context, roomID, bulletID string) (*entity.ShotResult, error) {
+	var result entity.ShotResult
+	body := map[string]interface{}{"room_id": roomID, "bullet_id": bulletID}
+	if err := c.do(ctx, "fire", http.MethodPost, "/api/v1/shoot/fire", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *client) hit(ctx context.Context, roomID, bulletID, fishUID string) (*entity.ShotResult, error) {
+	var result entity.ShotResult
+	body := map[string]interface{}{"room_id": roomID, "bullet_id": bulletID, "fish_uid": fishUID}
+	if err := c.do(ctx, "hit", http.MethodPost, "/api/v1/shoot/hit", body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *client) spawn(ctx context.Context, roomID string, fishID int, fishUID string, pathID int) error {
+	body := map[string]interface{}{"fish_id": fishID, "fish_uid": fishUID, "path_id": pathID}
+	return c.do(ctx, "spawn", http.MethodPost, "/api/v1/fish/"+url.PathEscape(roomID)+"/spawn", body, nil)
+}
+
+func (c *client) escape(ctx context.Context, roomID, fishUID string) error {
+	return c.do(ctx, "escape", http.MethodPost, "/api/v1/fish/"+url.PathEscape(roomID)+"/"+url.PathEscape(fishUID)+"/escape", nil, nil)
+}
+
+// dialRoom opens the room socket, passing the token as a query parameter
+// the way browsers do.
+func (c *client) dialRoom(ctx context.Context, roomID string) (*websocket.Conn, error) {
+	u := strings.Replace(c.base, "http", "ws", 1) + "/ws/rooms/" + url.PathEscape(roomID) + "?token=" + url.QueryEscape(c.token)
+	start := time.Now()
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u, nil)
+	c.stats.record("ws_connect", time.Since(start), err)
+	return conn, err
+}
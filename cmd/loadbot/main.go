@@ -0,0 +1,185 @@
+// Command loadbot plays the game with many virtual players at once against
+// a running server and reports latency percentiles, errors by code and
+// throughput. Each room gets a host bot that creates it and keeps it stocked
+// with fish; every bot joins over REST, follows the room over its websocket,
+// changes guns and fires at live fish at the gun's fire rate.
+//
+//	go run ./cmd/loadbot -players 40 -duration 1m
+//	go run ./cmd/loadbot -addr http://staging:8080 -jwt-secret $SECRET -players 200 -ramp 20s -json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/config"
+)
+
+func main() {
+	cfg := config.Load()
+
+	var (
+		addr         = flag.String("addr", "http://localhost:8080", "server base URL")
+		secret       = flag.String("jwt-secret", cfg.Auth.JWTSecret, "HS256 secret the server verifies tokens with")
+		players      = flag.Int("players", 8, "number of virtual players")
+		roomSize     = flag.Int("room-size", 4, "players per room")
+		duration     = flag.Duration("duration", 30*time.Second, "how long to play")
+		ramp         = flag.Duration("ramp", 5*time.Second, "spread player starts over this long")
+		gameName     = flag.String("game", "", "game name rooms are created with")
+		buyIn        = flag.Int64("buy-in", 10000, "chips each player buys in with")
+		guns         = flag.String("guns", "1,2,3", "gun ids players pick from")
+		fish         = flag.String("fish", "1,2,3,4,5", "fish ids hosts spawn")
+		fishAlive    = flag.Int("fish-alive", 10, "fish kept alive per room")
+		fishLife     = flag.Duration("fish-life", 30*time.Second, "how long a fish swims before it escapes")
+		gunChange    = flag.Duration("gun-change", 20*time.Second, "how often players change gun; 0 never")
+		resync       = flag.Duration("resync", 2*time.Second, "how often players resync over the websocket")
+		flight       = flag.Duration("flight", 300*time.Millisecond, "time between firing and reporting the hit")
+		fireInterval = flag.Duration("fire-interval", 0, "fixed time between shots; 0 uses the gun's fire rate")
+		runID        = flag.String("run-id", "", "prefix for room and player ids; defaults to a fresh one per run")
+		seed         = flag.Int64("seed", time.Now().UnixNano(), "random seed for targets, guns and timing")
+		asJSON       = flag.Bool("json", false, "print the report as JSON")
+	)
+	flag.Parse()
+
+	if *secret == "" {
+		log.Fatal("-jwt-secret or AUTH_JWT_SECRET is required")
+	}
+	if *players <= 0 || *roomSize <= 0 {
+		log.Fatal("-players and -room-size must be > 0")
+	}
+	gunIDs, err := parseIDs(*guns)
+	if err != nil {
+		log.Fatalf("-guns: %v", err)
+	}
+	fishIDs, err := parseIDs(*fish)
+	if err != nil {
+		log.Fatalf("-fish: %v", err)
+	}
+	if *runID == "" {
+		*runID = "lb" + strconv.FormatInt(time.Now().Unix()%1000000, 36)
+	}
+
+	s := &settings{
+		gameName:     *gameName,
+		roomSize:     *roomSize,
+		buyIn:        *buyIn,
+		guns:         gunIDs,
+		fish:         fishIDs,
+		fishAlive:    *fishAlive,
+		fishLife:     *fishLife,
+		gunChange:    *gunChange,
+		resync:       *resync,
+		flight:       *flight,
+		fireInterval: *fireInterval,
+	}
+	stats := newRecorder()
+	httpClient := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{MaxIdleConnsPerHost: *players * 2},
+	}
+	base := strings.TrimSuffix(*addr, "/")
+
+	var (
+		bots  []*bot
+		rooms []*room
+	)
+	for i := 0; i < *players; i++ {
+		seat := i % *roomSize
+		if seat == 0 {
+			rooms = append(rooms, &room{id: fmt.Sprintf("%s-r%d", *runID, len(rooms)+1), ready: make(chan struct{})})
+		}
+		r := rooms[len(rooms)-1]
+		playerID := fmt.Sprintf("%s-p%d", *runID, i+1)
+
+		roles := []string{middleware.RolePlayer}
+		if seat == 0 {
+			roles = append(roles, middleware.RoleOperator)
+		}
+		token, err := signToken([]byte(*secret), playerID, roles, *duration+time.Hour)
+		if err != nil {
+			log.Fatalf("Failed to sign token: %v", err)
+		}
+		bots = append(bots, &bot{
+			client:   &client{base: base, http: httpClient, token: token, stats: stats},
+			playerID: playerID,
+			seatID:   seat,
+			host:     seat == 0,
+			room:     r,
+			cfg:      s,
+			rng:      rand.New(rand.NewSource(*seed + int64(i))),
+		})
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// Cancel rather than time out, so calls cut short by the end of the run
+	// are not counted as timeouts.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	time.AfterFunc(*duration, cancel)
+
+	if !*asJSON {
+		fmt.Fprintf(os.Stderr, "run %s: %d players in %d rooms against %s for %s\n", *runID, len(bots), len(rooms), base, *duration)
+	}
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i, b := range bots {
+		delay := time.Duration(0)
+		if len(bots) > 1 {
+			delay = *ramp * time.Duration(i) / time.Duration(len(bots)-1)
+		}
+		wg.Add(1)
+		go func(b *bot, delay time.Duration) {
+			defer wg.Done()
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+			b.run(ctx)
+		}(b, delay)
+	}
+	<-ctx.Done()
+	elapsed := time.Since(start)
+	wg.Wait()
+
+	report := stats.report(len(bots), len(rooms), elapsed)
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	printReport(os.Stdout, report)
+}
+
+func parseIDs(list string) ([]int, error) {
+	var ids []int
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		id, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
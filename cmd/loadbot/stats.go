@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// recorder collects the latency and outcome of every call by operation.
+type recorder struct {
+	mu  sync.Mutex
+	ops map[string]*opStats
+
+	// Shot outcomes, for the observed RTP.
+	bet, won, kills int64
+}
+
+type opStats struct {
+	latencies []time.Duration
+	errors    map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{ops: map[string]*opStats{}}
+}
+
+// record adds one call. Calls cut short by the end of the run are dropped.
+func (r *recorder) record(op string, d time.Duration, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.ops[op]
+	if !ok {
+		s = &opStats{errors: map[string]int{}}
+		r.ops[op] = s
+	}
+	if err != nil {
+		s.errors[errorCode(err)]++
+		return
+	}
+	s.latencies = append(s.latencies, d)
+}
+
+func (r *recorder) recordCode(op, code string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.ops[op]
+	if !ok {
+		s = &opStats{errors: map[string]int{}}
+		r.ops[op] = s
+	}
+	s.errors[code]++
+}
+
+func (r *recorder) shot(cost, reward int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bet += cost
+	r.won += reward
+	if reward > 0 {
+		r.kills++
+	}
+}
+
+// OpReport summarises one operation. Latencies cover successful calls.
+type OpReport struct {
+	Op     string         `json:"op"`
+	OK     int            `json:"ok"`
+	Errors int            `json:"errors"`
+	PerSec float64        `json:"per_sec"`
+	P50    time.Duration  `json:"p50_ns"`
+	P90    time.Duration  `json:"p90_ns"`
+	P99    time.Duration  `json:"p99_ns"`
+	Max    time.Duration  `json:"max_ns"`
+	Codes  map[string]int `json:"codes,omitempty"`
+}
+
+// Report is the outcome of a run.
+type Report struct {
+	Players  int            `json:"players"`
+	Rooms    int            `json:"rooms"`
+	Elapsed  time.Duration  `json:"elapsed_ns"`
+	Requests int            `json:"requests"`
+	PerSec   float64        `json:"requests_per_sec"`
+	ShotsSec float64        `json:"shots_per_sec"`
+	Bet      int64          `json:"bet"`
+	Won      int64          `json:"won"`
+	Kills    int64          `json:"kills"`
+	Ops      []OpReport     `json:"ops"`
+	Codes    map[string]int `json:"error_codes"`
+}
+
+func (r *recorder) report(players, rooms int, elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep := &Report{Players: players, Rooms: rooms, Elapsed: elapsed, Bet: r.bet, Won: r.won, Kills: r.kills, Codes: map[string]int{}}
+	for op, s := range r.ops {
+		lat := append([]time.Duration(nil), s.latencies...)
+		sort.Slice(lat, func(i, j int) bool { return lat[i] < lat[j] })
+		o := OpReport{Op: op, OK: len(lat), Codes: s.errors}
+		for code, n := range s.errors {
+			o.Errors += n
+			rep.Codes[code] += n
+		}
+		if len(lat) > 0 {
+			o.P50, o.P90, o.P99, o.Max = percentile(lat, 50), percentile(lat, 90), percentile(lat, 99), lat[len(lat)-1]
+		}
+		o.PerSec = float64(o.OK+o.Errors) / elapsed.Seconds()
+		rep.Requests += o.OK + o.Errors
+		if op == "fire" {
+			rep.ShotsSec = float64(o.OK) / elapsed.Seconds()
+		}
+		rep.Ops = append(rep.Ops, o)
+	}
+	sort.Slice(rep.Ops, func(i, j int) bool { return rep.Ops[i].Op < rep.Ops[j].Op })
+	rep.PerSec = float64(rep.Requests) / elapsed.Seconds()
+	return rep
+}
+
+// percentile returns the nearest-rank percentile of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p+99)/100 - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+func printReport(out io.Writer, r *Report) {
+	fmt.Fprintf(out, "== %d players in %d rooms for %s\n", r.Players, r.Rooms, r.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(out, "throughput %.1f req/s   shots %.1f/s   kills %d", r.PerSec, r.ShotsSec, r.Kills)
+	if r.Bet > 0 {
+		fmt.Fprintf(out, "   observed RTP %.4f (bet %d, won %d)", float64(r.Won)/float64(r.Bet), r.Bet, r.Won)
+	}
+	fmt.Fprint(out, "\n\n")
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "op\tok\terrors\treq/s\tp50\tp90\tp99\tmax\t")
+	for _, o := range r.Ops {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t\n", o.Op, o.OK, o.Errors, o.PerSec, ms(o.P50), ms(o.P90), ms(o.P99), ms(o.Max))
+	}
+	w.Flush()
+
+	if len(r.Codes) == 0 {
+		return
+	}
+	fmt.Fprintln(out, "\nerrors by code:")
+	codes := make([]string, 0, len(r.Codes))
+	for code := range r.Codes {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return r.Codes[codes[i]] > r.Codes[codes[j]] })
+	for _, code := range codes {
+		fmt.Fprintf(out, "  %8d  %s\n", r.Codes[code], code)
+	}
+}
+
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
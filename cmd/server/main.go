@@ -1,88 +1,117 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/mongo"
-	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/redis"
-	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http"
+	"github.com/BT2701/backend-fishing-gameplay/internal/app"
 	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/config"
 	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
-	infmongo "github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/persistence/mongo"
-	infredis "github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/persistence/redis"
-	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/server"
-	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
 	"go.uber.org/zap"
 )
 
 func main() {
+	// Load configuration
+	cfg := config.Load()
+
 	// Initialize logger
-	if err := logger.Init(); err != nil {
+	if err := logger.Init(cfg.Log.Level); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
 	}
 	defer logger.Close()
 
 	zapLogger := logger.Get()
 
-	// Load configuration
-	cfg := config.Load()
+	// Components are created through the factories named in config
+	container := app.New(cfg, zapLogger, app.DefaultDrivers())
 
-	// Connect to MongoDB with retry
-	mongoClient, err := infmongo.ConnectWithRetryZap(
-		cfg.Mongo.URI,
-		cfg.Mongo.Database,
-		cfg.Mongo.Timeout,
-		cfg.Mongo.MaxRetries,
-		cfg.Mongo.RetryDelay,
-		zapLogger,
-	)
-	if err != nil {
-		zapLogger.Fatal("Failed to connect to MongoDB after retries", zap.Error(err))
+	// validate-config only needs the database: it checks the stored game
+	// config and exits without starting the server.
+	if len(os.Args) > 1 && os.Args[1] == "validate-config" {
+		gameConfigUsecase, err := container.ConfigValidator(context.Background())
+		code := 2
+		if err != nil {
+			zapLogger.Error("Failed to connect to the database", zap.Error(err))
+		} else {
+			code = runValidateConfig(context.Background(), gameConfigUsecase, os.Args[2:])
+		}
+		stop(container, 10*time.Second, zapLogger)
+		logger.Close()
+		os.Exit(code)
 	}
-	defer infmongo.Close(mongoClient)
-
-	mongoDB := mongoClient.Database(cfg.Mongo.Database)
-
-	// Connect to Redis with retry
-	redisClient, err := infredis.ConnectWithRetryZap(
-		cfg.Redis.Addr,
-		cfg.Redis.Password,
-		cfg.Redis.DB,
-		cfg.Redis.MaxRetries,
-		cfg.Redis.RetryDelay,
-		zapLogger,
-	)
+
+	if cfg.Auth.JWTSecret == "" {
+		zapLogger.Fatal("AUTH_JWT_SECRET must be set")
+	}
+
+	if err := container.Build(context.Background()); err != nil {
+		stop(container, 10*time.Second, zapLogger)
+		zapLogger.Fatal("Failed to build application", zap.Error(err))
+	}
+
+	// Check that every game's config documents agree with each other
+	gameConfigUsecase := container.Usecases.GameConfig
+	if err := validateGameConfigs(context.Background(), gameConfigUsecase, cfg.GameConfig.ValidateOnStart, zapLogger); err != nil {
+		stop(container, 10*time.Second, zapLogger)
+		zapLogger.Fatal("Refusing to start with unchecked or inconsistent game config", zap.Error(err))
+	}
+
+	// Load every game's config into the caches before taking traffic
+	warmUpCtx, cancelWarmUp := context.WithTimeout(context.Background(), 30*time.Second)
+	warmed, err := gameConfigUsecase.WarmUp(warmUpCtx)
+	cancelWarmUp()
 	if err != nil {
-		zapLogger.Fatal("Failed to connect to Redis after retries", zap.Error(err))
+		zapLogger.Warn("Game config warm-up incomplete", zap.Int("loaded", warmed), zap.Error(err))
+	} else {
+		zapLogger.Info("Game config warmed up", zap.Int("loaded", warmed))
+	}
+
+	// Start background workers and the server
+	if err := container.Start(context.Background()); err != nil {
+		stop(container, 10*time.Second, zapLogger)
+		zapLogger.Fatal("Failed to start application", zap.Error(err))
+	}
+
+	signals, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	select {
+	case err := <-container.Errors():
+		stop(container, 10*time.Second, zapLogger)
+		zapLogger.Fatal("Server stopped", zap.Error(err))
+	case <-signals.Done():
+	}
+
+	// Shut down within the configured deadline; if a step hangs, exit anyway
+	// rather than wait for the orchestrator to kill the process.
+	timeout := time.Duration(cfg.Server.ShutdownTimeout) * time.Second
+	if !stop(container, timeout, zapLogger) {
+		zapLogger.Error("Shutdown deadline exceeded; exiting", zap.Duration("timeout", timeout))
+		logger.Close()
+		os.Exit(1)
 	}
+	zapLogger.Info("Shutdown complete")
+}
+
+// stop stops the container and reports whether it finished within timeout.
+func stop(container *app.Container, timeout time.Duration, zapLogger *zap.Logger) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	// Initialize repositories
-	roomRepo := mongo.NewRoomRepository(mongoDB)
-	playerRepo := mongo.NewPlayerRepository(mongoDB)
-	fishRepo := mongo.NewFishRepository(mongoDB)
-	gunRepo := mongo.NewGunRepository(mongoDB)
-	rtpRepo := redis.NewRTPRepository(redisClient)
-	gameConfigMongoRepo := mongo.NewGameConfigRepository(mongoDB)
-
-	// Initialize cache repositories (with fallback to MongoDB)
-	gameConfigRepo := redis.NewGameConfigCacheRepository(redisClient, gameConfigMongoRepo, cfg.Redis.CacheTTL)
-
-	// Initialize usecases
-	roomUsecase := usecase.NewRoomUsecase(roomRepo, playerRepo)
-	fishUsecase := usecase.NewFishUsecase(roomRepo, fishRepo)
-	shootUsecase := usecase.NewShootUsecase(roomRepo, playerRepo, fishRepo, gunRepo, rtpRepo)
-	rtpUsecase := usecase.NewRTPUsecase(rtpRepo)
-	skillUsecase := usecase.NewSkillUsecase(playerRepo)
-	gameConfigUsecase := usecase.NewGameConfigUsecase(gameConfigRepo)
-
-	// Initialize HTTP server
-	srv := server.New(cfg.Server.Host, cfg.Server.Port, zapLogger)
-
-	// Setup routes
-	http.SetupRoutes(srv.GetApp(), roomUsecase, fishUsecase, shootUsecase, rtpUsecase, skillUsecase, gameConfigUsecase)
-
-	// Start server
-	if err := srv.Start(); err != nil {
-		zapLogger.Fatal("Failed to start server", zap.Error(err))
+	done := make(chan struct{})
+	go func() {
+		if err := container.Stop(ctx); err != nil {
+			zapLogger.Warn("Shutdown incomplete", zap.Error(err))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
 	}
 }
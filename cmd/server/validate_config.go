@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
+)
+
+// runValidateConfig implements the validate-config subcommand. It prints
+// every violation in the stored game config and returns the process exit
+// code: 0 when all games are consistent, 1 when any violation is found and 2
+// when the check itself fails.
+func runValidateConfig(ctx context.Context, gameConfigUsecase *usecase.GameConfigUsecase, args []string) int {
+	fs := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	gameName := fs.String("game", "", "validate only this game (default: every game with config)")
+	asJSON := fs.Bool("json", false, "print violations as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	result := map[string][]gameBaseModels.Violation{}
+	if *gameName != "" {
+		violations, err := gameConfigUsecase.ValidateGame(ctx, *gameName)
+		if err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				fmt.Fprintf(os.Stderr, "no config found for game %s\n", *gameName)
+			} else {
+				fmt.Fprintf(os.Stderr, "validate %s: %v\n", *gameName, err)
+			}
+			return 2
+		}
+		if len(violations) > 0 {
+			result[*gameName] = violations
+		}
+	} else {
+		var err error
+		result, err = gameConfigUsecase.ValidateAll(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "validate config: %v\n", err)
+			return 2
+		}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			fmt.Fprintf(os.Stderr, "encode result: %v\n", err)
+			return 2
+		}
+	} else {
+		gameNames := make([]string, 0, len(result))
+		for name := range result {
+			gameNames = append(gameNames, name)
+		}
+		sort.Strings(gameNames)
+		for _, name := range gameNames {
+			fmt.Printf("%s: %d violation(s)\n", name, len(result[name]))
+			for _, v := range result[name] {
+				fmt.Printf("  %s\n", v)
+			}
+		}
+		if len(result) == 0 {
+			fmt.Println("game config OK")
+		}
+	}
+
+	if len(result) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// validateGameConfigs runs the consistency check at startup. In "warn" mode
+// violations and a failure to run the check are logged; in "strict" mode
+// either is returned, and the server refuses to start.
+func validateGameConfigs(ctx context.Context, gameConfigUsecase *usecase.GameConfigUsecase, mode string, zapLogger *zap.Logger) error {
+	if mode == "off" {
+		return nil
+	}
+
+	result, err := gameConfigUsecase.ValidateAll(ctx)
+	if err != nil {
+		if mode == "strict" {
+			return fmt.Errorf("validate game config: %w", err)
+		}
+		zapLogger.Error("Game config validation failed", zap.Error(err))
+		return nil
+	}
+	for gameName, violations := range result {
+		for _, v := range violations {
+			zapLogger.Warn("Game config violation",
+				zap.String("game_name", gameName),
+				zap.String("kind", v.Kind),
+				zap.String("field", v.Field),
+				zap.String("message", v.Message),
+			)
+		}
+	}
+	if len(result) > 0 && mode == "strict" {
+		return fmt.Errorf("game config of %d game(s) is inconsistent", len(result))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/mongo"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	infmongo "github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/persistence/mongo"
+)
+
+// gameDocs is the subset of a game's configuration the simulator needs.
+type gameDocs struct {
+	Bullets   *gameBaseModels.BulletConfig
+	RTP       *gameBaseModels.GameRTP
+	FishTypes *gameBaseModels.GameFishTypes
+}
+
+func loadFromMongo(ctx context.Context, uri, database string, timeout int, gameName string) (*gameDocs, error) {
+	client, err := infmongo.Connect(uri, database, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("connect to MongoDB: %w", err)
+	}
+	defer infmongo.Close(client)
+
+	repo := mongo.NewGameConfigRepository(client.Database(database))
+	docs := &gameDocs{}
+	if docs.Bullets, err = repo.GetBulletConfig(ctx, gameName); err != nil {
+		return nil, fmt.Errorf("load bullets: %w", err)
+	}
+	if docs.RTP, err = repo.GetGameRTP(ctx, gameName); err != nil {
+		return nil, fmt.Errorf("load rtps: %w", err)
+	}
+	if docs.FishTypes, err = repo.GetGameFishTypes(ctx, gameName); err != nil {
+		return nil, fmt.Errorf("load types: %w", err)
+	}
+	return docs, nil
+}
+
+// loadFromDir reads bullets.json, rtps.json and types.json from dir. Each
+// file holds one document in the same shape as its Mongo collection.
+func loadFromDir(dir string) (*gameDocs, error) {
+	docs := &gameDocs{
+		Bullets:   &gameBaseModels.BulletConfig{},
+		RTP:       &gameBaseModels.GameRTP{},
+		FishTypes: &gameBaseModels.GameFishTypes{},
+	}
+	files := map[string]interface{}{
+		"bullets.json": docs.Bullets,
+		"rtps.json":    docs.RTP,
+		"types.json":   docs.FishTypes,
+	}
+	for name, dst := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, dst); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", name, err)
+		}
+	}
+	return docs, nil
+}
@@ -0,0 +1,129 @@
+// Command simulate runs Monte Carlo shots against a game's bullet, RTP and
+// fish type configuration and reports the realized RTP before it ships.
+//
+//	go run ./cmd/simulate -game ocean_hunter_v1 -shots 5000000 -seed 42
+//	go run ./cmd/simulate -source json -dir ./configs/ocean_hunter_v1 -bullet 2
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/BT2701/backend-fishing-gameplay/adapter/rng"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/sevices"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/config"
+	"go.uber.org/zap"
+)
+
+func main() {
+	cfg := config.Load()
+
+	var (
+		gameName    = flag.String("game", "", "game name to simulate")
+		source      = flag.String("source", "mongo", "config source: mongo or json")
+		dir         = flag.String("dir", ".", "directory holding bullets.json, rtps.json and types.json (json source)")
+		bulletID    = flag.Int("bullet", 0, "bullet id to fire; 0 simulates every bullet")
+		shots       = flag.Int64("shots", 1000000, "shots per bullet")
+		seed        = flag.Int64("seed", 1, "random seed; runs with the same seed are identical")
+		pool        = flag.Int("pool", 20, "number of fish alive at once")
+		bankroll    = flag.Int64("bankroll", 100000, "starting bankroll for the bankroll curve")
+		curvePoints = flag.Int("curve-points", 20, "number of bankroll curve samples")
+		asJSON      = flag.Bool("json", false, "print reports as JSON")
+	)
+	flag.Parse()
+	if *pool <= 0 {
+		log.Fatal("-pool must be > 0")
+	}
+
+	var (
+		docs *gameDocs
+		err  error
+	)
+	switch *source {
+	case "mongo":
+		if *gameName == "" {
+			log.Fatal("-game is required for the mongo source")
+		}
+		docs, err = loadFromMongo(context.Background(), cfg.Mongo.URI, cfg.Mongo.Database, cfg.Mongo.Timeout, *gameName)
+	case "json":
+		docs, err = loadFromDir(*dir)
+	default:
+		log.Fatalf("unknown source %q", *source)
+	}
+	if err != nil {
+		log.Fatalf("Failed to load game config: %v", err)
+	}
+	if *gameName == "" {
+		*gameName = docs.FishTypes.GameName
+	}
+	if len(docs.FishTypes.Data.FishTypes) == 0 {
+		log.Fatal("game has no fish types")
+	}
+
+	var bullets []gameBaseModels.BulletInfo
+	for _, b := range docs.Bullets.Data.Bullets {
+		if *bulletID == 0 || b.BulletID == *bulletID {
+			bullets = append(bullets, b)
+		}
+	}
+	if len(bullets) == 0 {
+		log.Fatalf("no bullet with id %d", *bulletID)
+	}
+
+	seeded := rng.NewSeededRNG(*seed, zap.NewNop())
+	reports := make([]*Report, 0, len(bullets))
+	for _, b := range bullets {
+		// Each bullet gets its own streams so adding a bullet to the config
+		// does not change the results for the others.
+		stream := seeded.Stream(*gameName + ":" + strconv.Itoa(b.BulletID))
+		roller := gameBaseSevices.RollerFunc(func() float64 {
+			value, _ := stream.Draw()
+			return value
+		})
+		sc := buildScenario(*gameName, b, docs.RTP, docs.FishTypes)
+		report := simulate(sc, *shots, *pool, *bankroll, *curvePoints, rand.New(rand.NewSource(*seed+int64(b.BulletID))), roller)
+		report.Seed = *seed
+		reports = append(reports, report)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	for _, r := range reports {
+		printReport(r)
+	}
+}
+
+func printReport(r *Report) {
+	fmt.Printf("== %s / bullet %d (%s) — %d shots, seed %d\n", r.GameName, r.BulletID, r.BulletName, r.Shots, r.Seed)
+	fmt.Printf("RTP %.4f ± %.4f (95%%)   bet %d   win %d\n", r.RTP, r.RTPMargin95, r.TotalBet, r.TotalWin)
+	fmt.Printf("per-shot return variance %.4f (sd %.4f)   max win %d\n", r.Variance, r.StdDev, r.MaxWin)
+	fmt.Printf("bankroll start %d   min %d   end %d\n\n", r.StartBankroll, r.MinBankroll, r.EndBankroll)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "fish\tname\ttarget\thit rate\tshots\thit freq\tkill rate\tpaid\trtp\t")
+	for _, f := range r.Fish {
+		fmt.Fprintf(w, "%d\t%s\t%d%%\t%.4f\t%d\t%.4f\t%.5f\t%d\t%.4f\t\n",
+			f.FishID, f.Name, f.TargetRTP, f.HitRate, f.Shots, f.HitFrequency, f.KillRate, f.Paid, f.RTP)
+	}
+	w.Flush()
+
+	fmt.Println("\nbankroll curve:")
+	for _, p := range r.Curve {
+		fmt.Printf("  %12d  %d\n", p.Shot, p.Bankroll)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/sevices"
+)
+
+// simFish is a fish type in simulation, with the target RTP and hit rate
+// it plays at against the scenario's bullet kept for the report.
+type simFish struct {
+	Type      gameBaseModels.FishType
+	HitRate   float64
+	TargetRTP int
+}
+
+type scenario struct {
+	GameName string
+	Bullet   gameBaseModels.BulletInfo
+	RTP      gameBaseModels.RTPData
+	Fish     []simFish
+}
+
+type FishStats struct {
+	FishID       int     `json:"fish_id"`
+	Name         string  `json:"name"`
+	TargetRTP    int     `json:"target_rtp"`
+	HitRate      float64 `json:"hit_rate"`
+	Shots        int64   `json:"shots"`
+	Hits         int64   `json:"hits"`
+	Kills        int64   `json:"kills"`
+	HitFrequency float64 `json:"hit_frequency"`
+	KillRate     float64 `json:"kill_rate"`
+	Bet          int64   `json:"bet"`
+	Paid         int64   `json:"paid"`
+	RTP          float64 `json:"rtp"`
+}
+
+type CurvePoint struct {
+	Shot     int64 `json:"shot"`
+	Bankroll int64 `json:"bankroll"`
+}
+
+type Report struct {
+	GameName      string       `json:"game_name"`
+	BulletID      int          `json:"bullet_id"`
+	BulletName    string       `json:"bullet_name"`
+	Seed          int64        `json:"seed"`
+	Shots         int64        `json:"shots"`
+	TotalBet      int64        `json:"total_bet"`
+	TotalWin      int64        `json:"total_win"`
+	RTP           float64      `json:"rtp"`
+	Variance      float64      `json:"variance"` // of the per-shot return (win / cost)
+	StdDev        float64      `json:"std_dev"`
+	RTPMargin95   float64      `json:"rtp_margin_95"`
+	MaxWin        int64        `json:"max_win"`
+	StartBankroll int64        `json:"start_bankroll"`
+	MinBankroll   int64        `json:"min_bankroll"`
+	EndBankroll   int64        `json:"end_bankroll"`
+	Curve         []CurvePoint `json:"bankroll_curve"`
+	Fish          []FishStats  `json:"fish"`
+}
+
+type liveFish struct {
+	typeIdx int
+	hp      int
+}
+
+// simulate fires shots at a pool of live fish spawned by SpawnRate weight,
+// resolving each through the same gameBaseSevices.HitInputFor and
+// ResolveHit the shoot usecase uses. Hit rolls come from roller, as they do
+// in production; spawns and targets come from rng. Fixed seeds reproduce
+// the report exactly.
+func simulate(sc scenario, shots int64, poolSize int, bankroll int64, curvePoints int, rng *rand.Rand, roller gameBaseSevices.Roller) *Report {
+	report := &Report{
+		GameName:      sc.GameName,
+		BulletID:      sc.Bullet.BulletID,
+		BulletName:    sc.Bullet.Name,
+		Shots:         shots,
+		StartBankroll: bankroll,
+		MinBankroll:   bankroll,
+		Fish:          make([]FishStats, len(sc.Fish)),
+	}
+	for i, f := range sc.Fish {
+		report.Fish[i] = FishStats{FishID: f.Type.FishID, Name: f.Type.FishName, TargetRTP: f.TargetRTP, HitRate: f.HitRate}
+	}
+
+	totalWeight := 0
+	for _, f := range sc.Fish {
+		totalWeight += f.Type.SpawnRate
+	}
+	spawn := func() liveFish {
+		idx := 0
+		if totalWeight > 0 {
+			pick := rng.Intn(totalWeight)
+			for i, f := range sc.Fish {
+				if pick < f.Type.SpawnRate {
+					idx = i
+					break
+				}
+				pick -= f.Type.SpawnRate
+			}
+		} else {
+			idx = rng.Intn(len(sc.Fish))
+		}
+		return liveFish{typeIdx: idx, hp: sc.Fish[idx].Type.HP}
+	}
+
+	pool := make([]liveFish, poolSize)
+	for i := range pool {
+		pool[i] = spawn()
+	}
+
+	cost := int64(sc.Bullet.Cost)
+	curveEvery := int64(1)
+	if curvePoints > 0 && shots > int64(curvePoints) {
+		curveEvery = shots / int64(curvePoints)
+	}
+
+	var sumReturn, sumReturnSq float64
+	for n := int64(1); n <= shots; n++ {
+		slot := rng.Intn(len(pool))
+		target := &pool[slot]
+		fish := &sc.Fish[target.typeIdx].Type
+		stats := &report.Fish[target.typeIdx]
+
+		outcome := gameBaseSevices.ResolveHit(roller, gameBaseSevices.HitInputFor(&sc.RTP, fish, &sc.Bullet, target.hp))
+
+		stats.Shots++
+		stats.Bet += cost
+		report.TotalBet += cost
+		if outcome.Hit {
+			stats.Hits++
+			target.hp = outcome.RemainingHP
+		}
+		if outcome.Killed {
+			stats.Kills++
+			stats.Paid += outcome.Payout
+			report.TotalWin += outcome.Payout
+			if outcome.Payout > report.MaxWin {
+				report.MaxWin = outcome.Payout
+			}
+			*target = spawn()
+		}
+
+		bankroll += outcome.Payout - cost
+		if bankroll < report.MinBankroll {
+			report.MinBankroll = bankroll
+		}
+		if cost > 0 {
+			r := float64(outcome.Payout) / float64(cost)
+			sumReturn += r
+			sumReturnSq += r * r
+		}
+		if n%curveEvery == 0 || n == shots {
+			report.Curve = append(report.Curve, CurvePoint{Shot: n, Bankroll: bankroll})
+		}
+	}
+
+	report.EndBankroll = bankroll
+	if report.TotalBet > 0 {
+		report.RTP = float64(report.TotalWin) / float64(report.TotalBet)
+	}
+	if shots > 1 {
+		mean := sumReturn / float64(shots)
+		report.Variance = (sumReturnSq - float64(shots)*mean*mean) / float64(shots-1)
+		report.StdDev = math.Sqrt(report.Variance)
+		report.RTPMargin95 = 1.96 * report.StdDev / math.Sqrt(float64(shots))
+	}
+	for i := range report.Fish {
+		s := &report.Fish[i]
+		if s.Shots > 0 {
+			s.HitFrequency = float64(s.Hits) / float64(s.Shots)
+			s.KillRate = float64(s.Kills) / float64(s.Shots)
+		}
+		if s.Bet > 0 {
+			s.RTP = float64(s.Paid) / float64(s.Bet)
+		}
+	}
+	return report
+}
+
+// buildScenario sets up one bullet against every fish type of a game.
+func buildScenario(gameName string, bullet gameBaseModels.BulletInfo, rtp *gameBaseModels.GameRTP, fishTypes *gameBaseModels.GameFishTypes) scenario {
+	sc := scenario{GameName: gameName, Bullet: bullet, RTP: rtp.Data}
+	for _, ft := range fishTypes.Data.FishTypes {
+		in := gameBaseSevices.HitInputFor(&rtp.Data, &ft, &bullet, ft.HP)
+		sc.Fish = append(sc.Fish, simFish{
+			Type:      ft,
+			HitRate:   in.HitRate,
+			TargetRTP: gameBaseSevices.TargetRTP(&rtp.Data, ft.FishID, bullet.BulletID),
+		})
+	}
+	return sc
+}
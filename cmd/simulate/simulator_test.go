@@ -0,0 +1,55 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/adapter/rng"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/sevices"
+	"go.uber.org/zap"
+)
+
+func reefScenario() scenario {
+	bullet := gameBaseModels.BulletInfo{BulletID: 1, Name: "cannon", Cost: 10, Damage: 20}
+	rtp := &gameBaseModels.GameRTP{Data: gameBaseModels.RTPData{RTPRate: 95}}
+	fishTypes := &gameBaseModels.GameFishTypes{Data: gameBaseModels.FishTypeData{FishTypes: []gameBaseModels.FishType{
+		{FishID: 1, FishName: "minnow", HP: 20, BaseReward: 30, Multiplier: 2, SpawnRate: 70},
+		{FishID: 2, FishName: "grouper", HP: 100, BaseReward: 100, Multiplier: 3, SpawnRate: 30},
+	}}}
+	return buildScenario("reef", bullet, rtp, fishTypes)
+}
+
+func runReef(seed, shots int64) *Report {
+	stream := rng.NewSeededRNG(seed, zap.NewNop()).Stream("reef:1")
+	roller := gameBaseSevices.RollerFunc(func() float64 {
+		value, _ := stream.Draw()
+		return value
+	})
+	return simulate(reefScenario(), shots, 20, 100000, 10, rand.New(rand.NewSource(seed+1)), roller)
+}
+
+func TestSameSeedSimulatesTheSame(t *testing.T) {
+	first, second := runReef(42, 50000), runReef(42, 50000)
+	if first.TotalBet != second.TotalBet || first.TotalWin != second.TotalWin || first.EndBankroll != second.EndBankroll {
+		t.Fatalf("seed 42 played differently: bet %d/%d, win %d/%d, bankroll %d/%d",
+			first.TotalBet, second.TotalBet, first.TotalWin, second.TotalWin, first.EndBankroll, second.EndBankroll)
+	}
+	for i := range first.Fish {
+		if first.Fish[i].Kills != second.Fish[i].Kills {
+			t.Fatalf("fish %d kills = %d then %d", first.Fish[i].FishID, first.Fish[i].Kills, second.Fish[i].Kills)
+		}
+	}
+}
+
+func TestSimulatedRTPMatchesTheConfig(t *testing.T) {
+	report := runReef(7, 500000)
+	if report.TotalBet != 500000*10 {
+		t.Fatalf("total bet = %d, want %d", report.TotalBet, 500000*10)
+	}
+	// Three standard errors either side of the configured 95%.
+	if diff := math.Abs(report.RTP - 0.95); diff > 1.5*report.RTPMargin95 {
+		t.Fatalf("RTP = %.4f ± %.4f, want 0.95", report.RTP, report.RTPMargin95)
+	}
+}
@@ -0,0 +1,309 @@
+// Package app assembles the server: it creates the database, cache and HTTP
+// server through the contract factories selected in config, wires
+// repositories, usecases and routes on top of them, and starts and stops
+// everything in dependency order.
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/mongo"
+	"github.com/BT2701/backend-fishing-gameplay/adapter/rng"
+	"github.com/BT2701/backend-fishing-gameplay/adapter/wallet"
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http"
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/ws"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/config"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/contract"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/health"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/metrics"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/tracing"
+	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const shutdownReason = "server is restarting; reconnect to continue playing"
+
+// Usecases holds the usecases served by the HTTP and websocket handlers.
+type Usecases struct {
+	Room       *usecase.RoomUsecase
+	Fish       *usecase.FishUsecase
+	Shoot      *usecase.ShootUsecase
+	RTP        *usecase.RTPUsecase
+	Skill      *usecase.SkillUsecase
+	GameConfig *usecase.GameConfigUsecase
+	Sync       *usecase.SyncUsecase
+	Fairness   *usecase.FairnessUsecase
+}
+
+// Container owns every component of the running server. Components are
+// exported once Build has created them.
+type Container struct {
+	cfg       *config.Config
+	logger    *zap.Logger
+	log       contract.Logger
+	drivers   Drivers
+	lifecycle Lifecycle
+	workers   []worker
+	serveErr  chan error
+
+	Database     contract.Database
+	Cache        contract.Cache
+	Server       contract.Server
+	Repositories Repositories
+	Usecases     Usecases
+	Hub          *ws.Hub
+	Health       *health.Checker
+}
+
+func New(cfg *config.Config, zapLogger *zap.Logger, drivers Drivers) *Container {
+	return &Container{
+		cfg:      cfg,
+		logger:   zapLogger,
+		log:      logger.NewZapLoggerAdapter(zapLogger),
+		drivers:  drivers,
+		serveErr: make(chan error, 1),
+	}
+}
+
+// ConfigValidator connects only the database and returns a game config
+// usecase reading straight from it, for the validate-config subcommand.
+func (c *Container) ConfigValidator(ctx context.Context) (*usecase.GameConfigUsecase, error) {
+	if err := c.connectDatabase(ctx); err != nil {
+		return nil, err
+	}
+	if c.cfg.Storage.Mode == "memory" {
+		store, err := c.memoryStore()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewGameConfigUsecase(store.GameConfig, store.GameConfig, store.GameConfigVersions, nil), nil
+	}
+	mongoDB, err := c.mongoDatabase()
+	if err != nil {
+		return nil, err
+	}
+	store := mongo.NewGameConfigRepository(mongoDB)
+	return usecase.NewGameConfigUsecase(store, store, mongo.NewGameConfigVersionRepository(mongoDB), nil), nil
+}
+
+// Build connects the database and cache and creates everything that runs on
+// them. Connections opened before a failure are closed by Stop.
+func (c *Container) Build(ctx context.Context) error {
+	c.lifecycle.Append(c.tracingHook())
+	if err := c.connectDatabase(ctx); err != nil {
+		return err
+	}
+	if err := c.connectCache(ctx); err != nil {
+		return err
+	}
+	if err := c.buildRepositories(); err != nil {
+		return err
+	}
+	c.Hub = ws.NewHub(c.logger)
+	if err := c.buildUsecases(); err != nil {
+		return err
+	}
+
+	// Readiness pings the database and cache through their contracts
+	c.Health = health.NewChecker(time.Duration(c.cfg.Health.CheckTimeoutMs) * time.Millisecond)
+	c.Health.Register(c.cfg.Drivers.Database, c.Database)
+	c.Health.Register(c.cfg.Drivers.Cache, c.Cache)
+
+	if err := c.buildServer(); err != nil {
+		return err
+	}
+
+	// Settle wallet transfers left pending by outages or crashes
+	c.addWorker("wallet reconciliation", c.reconcileWalletTransfers(time.Duration(c.cfg.Wallet.ReconcileInterval)*time.Second))
+
+	// Refund expired bullets in rooms where nobody is shooting
+	c.addWorker("bullet sweep", c.sweepExpiredBullets(time.Duration(c.cfg.Shoot.SweepIntervalMs)*time.Millisecond))
+
+	c.lifecycle.Append(c.workersHook())
+	c.lifecycle.Append(c.serverHook())
+	return nil
+}
+
+// Start starts the background workers and the server. Serving errors are
+// reported on Errors.
+func (c *Container) Start(ctx context.Context) error {
+	return c.lifecycle.Start(ctx)
+}
+
+// Stop stops everything that was started, in reverse order, giving up on
+// each step when ctx ends.
+func (c *Container) Stop(ctx context.Context) error {
+	return c.lifecycle.Stop(ctx)
+}
+
+// Errors receives the error the server stopped with if it stops on its own.
+func (c *Container) Errors() <-chan error {
+	return c.serveErr
+}
+
+func (c *Container) tracingHook() Hook {
+	var flush func(context.Context) error
+	return Hook{
+		Name: "tracing",
+		OnStart: func(ctx context.Context) (err error) {
+			flush, err = tracing.Init(ctx, c.cfg.Tracing)
+			return err
+		},
+		OnStop: func(ctx context.Context) error {
+			return flush(ctx)
+		},
+	}
+}
+
+func (c *Container) connectDatabase(ctx context.Context) error {
+	factory, err := c.drivers.database(c.cfg.Drivers.Database)
+	if err != nil {
+		return err
+	}
+	c.lifecycle.Append(Hook{
+		Name: "database",
+		OnStart: func(context.Context) (err error) {
+			c.Database, err = factory.CreateDatabase(c.cfg, c.log)
+			return err
+		},
+		OnStop: func(context.Context) error {
+			return factory.CloseDatabase(c.Database)
+		},
+	})
+	return c.lifecycle.Start(ctx)
+}
+
+func (c *Container) connectCache(ctx context.Context) error {
+	factory, err := c.drivers.cache(c.cfg.Drivers.Cache)
+	if err != nil {
+		return err
+	}
+	c.lifecycle.Append(Hook{
+		Name: "cache",
+		OnStart: func(context.Context) (err error) {
+			c.Cache, err = factory.CreateCache(c.cfg, c.log)
+			return err
+		},
+		OnStop: func(context.Context) error {
+			return factory.CloseCache(c.Cache)
+		},
+	})
+	return c.lifecycle.Start(ctx)
+}
+
+func (c *Container) buildUsecases() error {
+	var walletProvider port.WalletProvider
+	switch c.cfg.Wallet.Provider {
+	case "http":
+		if c.cfg.Wallet.BaseURL == "" {
+			return fmt.Errorf("WALLET_BASE_URL is required for the http wallet provider")
+		}
+		walletProvider = wallet.NewHTTPWalletProvider(c.cfg.Wallet.BaseURL, c.cfg.Wallet.APIKey, c.cfg.Wallet.TimeoutMs)
+	case "memory":
+		// Every unknown player starts with DevBalance chips, which must not
+		// happen against real player records by accident.
+		if c.cfg.Drivers.Database != "memory" && !c.cfg.Wallet.AllowMemory {
+			return fmt.Errorf("memory wallet provider on %s storage gives new players free chips; set WALLET_ALLOW_MEMORY=true to use it anyway", c.cfg.Drivers.Database)
+		}
+		c.logger.Warn("Using in-memory wallet provider; balances are not persisted")
+		walletProvider = wallet.NewMemoryWalletProvider(c.cfg.Wallet.DevBalance)
+	default:
+		return fmt.Errorf("unknown wallet provider %q", c.cfg.Wallet.Provider)
+	}
+
+	var gameRNG port.RNG
+	switch c.cfg.RNG.Mode {
+	case "crypto":
+		gameRNG = rng.NewCryptoRNG(c.logger)
+	case "seeded":
+		c.logger.Warn("Using seeded RNG; outcomes are predictable", zap.Int64("seed", c.cfg.RNG.Seed))
+		gameRNG = rng.NewSeededRNG(c.cfg.RNG.Seed, c.logger)
+	default:
+		return fmt.Errorf("unknown RNG mode %q", c.cfg.RNG.Mode)
+	}
+
+	// Streams still live at shutdown are retired once the server has stopped
+	// drawing from them
+	c.lifecycle.Append(Hook{
+		Name: "rng",
+		OnStop: func(context.Context) error {
+			gameRNG.ReleaseAll()
+			return nil
+		},
+	})
+
+	r := c.Repositories
+	shotLogs := logger.NewSampler(time.Second, c.cfg.Log.ShotSampleFirst, c.cfg.Log.ShotSampleThereafter)
+	// Room mutations are pushed to the room's sockets as they are saved
+	publish := usecase.WithPublisher(c.Hub)
+	c.Usecases = Usecases{
+		Room:       usecase.NewRoomUsecase(r.Rooms, r.Players, walletProvider, r.WalletTransfers, r.FairSessions, r.Events, r.RTP, r.GameConfigStore, r.GameConfigVersions, publish, usecase.WithReleasers(gameRNG)),
+		Fish:       usecase.NewFishUsecase(r.Rooms, r.Fish, r.GameConfigStore, r.GameConfigVersions, r.Events, publish),
+		Shoot:      usecase.NewShootUsecase(r.Rooms, r.Players, r.Fish, r.Guns, r.GameConfigStore, r.GameConfigVersions, r.RTP, r.ShotResults, gameRNG, r.FairSessions, r.FairShots, r.Events, shotLogs, publish, usecase.WithMetrics(metrics.Gameplay{})),
+		RTP:        usecase.NewRTPUsecase(r.RTP),
+		Skill:      usecase.NewSkillUsecase(r.Rooms, r.Players, r.Events, publish),
+		GameConfig: usecase.NewGameConfigUsecase(r.GameConfig, r.GameConfigStore, r.GameConfigVersions, r.GameConfigCache),
+		Sync:       usecase.NewSyncUsecase(r.Rooms, r.Guns),
+		Fairness:   usecase.NewFairnessUsecase(r.Rooms, r.FairSessions, r.FairShots),
+	}
+	return nil
+}
+
+func (c *Container) buildServer() error {
+	factory, err := c.drivers.server(c.cfg.Drivers.Server)
+	if err != nil {
+		return err
+	}
+	c.Server, err = factory.CreateServer(c.cfg.Server.Host, c.cfg.Server.Port, c.log)
+	if err != nil {
+		return err
+	}
+	app, ok := c.Server.GetNative().(*fiber.App)
+	if !ok {
+		return fmt.Errorf("server driver %q does not provide a Fiber app", c.cfg.Drivers.Server)
+	}
+
+	u := c.Usecases
+	http.SetupRoutes(app, u.Room, u.Fish, u.Shoot, u.RTP, u.Skill, u.GameConfig, u.Sync, u.Fairness, c.Hub, c.Health, c.cfg.Auth.JWTSecret)
+	return nil
+}
+
+// serverHook serves on its own goroutine. On stop it fails readiness and
+// refuses joins, waits for the load balancer to notice, tells websocket
+// clients to reconnect elsewhere and lets in-flight requests finish. Room,
+// player and RTP state is written through on every request, so nothing is
+// left buffered once the server has stopped.
+func (c *Container) serverHook() Hook {
+	return Hook{
+		Name: "server",
+		OnStart: func(context.Context) error {
+			go func() {
+				if err := c.Server.Start(); err != nil {
+					c.serveErr <- err
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			c.Health.SetShuttingDown()
+			c.Usecases.Room.StopJoins()
+
+			drainDelay := time.Duration(c.cfg.Health.DrainDelay) * time.Second
+			c.logger.Info("Shutdown requested; draining", zap.Duration("drain_delay", drainDelay))
+			select {
+			case <-time.After(drainDelay):
+			case <-ctx.Done():
+			}
+
+			if err := c.Hub.Shutdown(ctx, ws.Message{Type: ws.MessageTypeShutdown, Data: shutdownReason}); err != nil {
+				c.logger.Warn("Websocket clients still connected at shutdown", zap.Error(err))
+			}
+			return c.Server.Shutdown(ctx)
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/BT2701/backend-fishing-gameplay/adapter/database"
+	"github.com/BT2701/backend-fishing-gameplay/adapter/server"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/contract"
+)
+
+// Drivers maps the names accepted by DATABASE_DRIVER, CACHE_DRIVER and
+// SERVER_DRIVER to the factories that build them. Tests can add doubles
+// under their own names.
+type Drivers struct {
+	Databases map[string]contract.DatabaseFactory
+	Caches    map[string]contract.CacheFactory
+	Servers   map[string]contract.ServerFactory
+}
+
+// DefaultDrivers returns the built-in factories.
+func DefaultDrivers() Drivers {
+	return Drivers{
+		Databases: map[string]contract.DatabaseFactory{
+			"mongo":  database.NewMongoDatabaseFactory(),
+			"memory": database.NewMemoryDatabaseFactory(),
+		},
+		Caches: map[string]contract.CacheFactory{
+			"redis":  database.NewRedisCacheFactory(),
+			"memory": database.NewMemoryCacheFactory(),
+		},
+		Servers: map[string]contract.ServerFactory{
+			"fiber": server.NewFiberServerFactory(),
+		},
+	}
+}
+
+func (d Drivers) database(name string) (contract.DatabaseFactory, error) {
+	if f, ok := d.Databases[name]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown database driver %q", name)
+}
+
+func (d Drivers) cache(name string) (contract.CacheFactory, error) {
+	if f, ok := d.Caches[name]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown cache driver %q", name)
+}
+
+func (d Drivers) server(name string) (contract.ServerFactory, error) {
+	if f, ok := d.Servers[name]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown server driver %q", name)
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Hook is a named start and stop step of the application. Either step may be
+// nil.
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle starts hooks in the order they were appended and stops the
+// started ones in reverse, so a component is stopped before anything it
+// depends on.
+type Lifecycle struct {
+	mu      sync.Mutex
+	hooks   []Hook
+	started int
+}
+
+func (l *Lifecycle) Append(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+}
+
+// Start runs OnStart of every hook appended since the previous call. It stops
+// at the first failure and returns it; hooks started so far stay started
+// until Stop.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.started < len(l.hooks) {
+		hook := l.hooks[l.started]
+		if hook.OnStart != nil {
+			if err := hook.OnStart(ctx); err != nil {
+				return fmt.Errorf("start %s: %w", hook.Name, err)
+			}
+		}
+		l.started++
+	}
+	return nil
+}
+
+// Stop runs OnStop of every started hook in reverse order. A failing hook
+// does not keep the ones after it from running; all errors are returned
+// joined.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var errs []error
+	for ; l.started > 0; l.started-- {
+		hook := l.hooks[l.started-1]
+		if hook.OnStop == nil {
+			continue
+		}
+		if err := hook.OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", hook.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,162 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/instrumented"
+	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/memory"
+	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/mongo"
+	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/redis"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/metrics"
+	redislib "github.com/redis/go-redis/v9"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+)
+
+// Repositories holds every port the usecases are built from.
+type Repositories struct {
+	Rooms              port.RoomRepository
+	Players            port.PlayerRepository
+	Fish               port.FishRepository
+	Guns               port.GunRepository
+	RTP                port.RTPRepository
+	ShotResults        port.ShotResultRepository
+	GameConfig         port.GameConfigRepository
+	GameConfigStore    port.GameConfigStore
+	GameConfigVersions port.GameConfigVersionStore
+	GameConfigCache    port.GameConfigCache
+	WalletTransfers    port.WalletTransferRepository
+	FairSessions       port.FairSessionRepository
+	FairShots          port.FairShotRepository
+	Events             port.EventStore
+}
+
+// mongoDatabase returns the configured database of the native client behind
+// the database driver.
+func (c *Container) mongoDatabase() (*mongodriver.Database, error) {
+	client, ok := c.Database.GetNative().(*mongodriver.Client)
+	if !ok {
+		return nil, fmt.Errorf("database driver %q does not provide a MongoDB client", c.cfg.Drivers.Database)
+	}
+	return client.Database(c.cfg.Mongo.Database), nil
+}
+
+// redisClient returns the native client behind the cache driver.
+func (c *Container) redisClient() (*redislib.Client, error) {
+	client, ok := c.Cache.GetNative().(*redislib.Client)
+	if !ok {
+		return nil, fmt.Errorf("cache driver %q does not provide a Redis client", c.cfg.Drivers.Cache)
+	}
+	return client, nil
+}
+
+func (c *Container) buildRepositories() error {
+	switch c.cfg.Storage.Mode {
+	case "mongo":
+		return c.buildMongoRepositories()
+	case "memory":
+		return c.buildMemoryRepositories()
+	default:
+		return fmt.Errorf("unknown storage mode %q", c.cfg.Storage.Mode)
+	}
+}
+
+// memoryStore returns the store behind the database driver.
+func (c *Container) memoryStore() (*memory.Store, error) {
+	store, ok := c.Database.GetNative().(*memory.Store)
+	if !ok {
+		return nil, fmt.Errorf("database driver %q does not provide a memory store", c.cfg.Drivers.Database)
+	}
+	return store, nil
+}
+
+// buildMongoRepositories creates the Mongo and Redis repositories, each
+// timed per call for /metrics, and registers the gauges read on scrape.
+func (c *Container) buildMongoRepositories() error {
+	mongoDB, err := c.mongoDatabase()
+	if err != nil {
+		return err
+	}
+	redisClient, err := c.redisClient()
+	if err != nil {
+		return err
+	}
+
+	mongoRoomRepo := mongo.NewRoomRepository(mongoDB)
+	gameConfigStore := instrumented.NewGameConfigStore(mongo.NewGameConfigRepository(mongoDB), "mongo")
+
+	// Cached config falls back to MongoDB
+	cacheTTLs := map[string]time.Duration{}
+	for kind, ttl := range c.cfg.GameConfig.CacheTTLs {
+		cacheTTLs[kind] = time.Duration(ttl) * time.Second
+	}
+	gameConfigCache := redis.NewGameConfigCacheRepository(redisClient, gameConfigStore, redis.GameConfigCacheOptions{
+		TTL:         time.Duration(c.cfg.Redis.CacheTTL) * time.Second,
+		TTLByKind:   cacheTTLs,
+		NegativeTTL: time.Duration(c.cfg.GameConfig.NegativeCacheTTL) * time.Second,
+		LocalSize:   c.cfg.GameConfig.LocalCacheSize,
+		LocalTTL:    time.Duration(c.cfg.GameConfig.LocalCacheTTL) * time.Second,
+	})
+
+	c.Repositories = Repositories{
+		Rooms:              instrumented.NewRoomRepository(mongoRoomRepo, "mongo"),
+		Players:            instrumented.NewPlayerRepository(mongo.NewPlayerRepository(mongoDB), "mongo"),
+		Fish:               instrumented.NewFishRepository(mongo.NewFishRepository(mongoDB), "mongo"),
+		Guns:               instrumented.NewGunRepository(mongo.NewGunRepository(mongoDB), "mongo"),
+		RTP:                instrumented.NewRTPRepository(redis.NewRTPRepository(redisClient), "redis"),
+		ShotResults:        instrumented.NewShotResultRepository(redis.NewShotResultRepository(redisClient), "redis"),
+		GameConfig:         instrumented.NewGameConfigRepository(gameConfigCache, "cache"),
+		GameConfigStore:    gameConfigStore,
+		GameConfigVersions: instrumented.NewGameConfigVersionStore(mongo.NewGameConfigVersionRepository(mongoDB), "mongo"),
+		GameConfigCache:    gameConfigCache,
+		WalletTransfers:    instrumented.NewWalletTransferRepository(mongo.NewWalletTransferRepository(mongoDB), "mongo"),
+		FairSessions:       instrumented.NewFairSessionRepository(mongo.NewFairSessionRepository(mongoDB), "mongo"),
+		FairShots:          instrumented.NewFairShotRepository(mongo.NewFairShotRepository(mongoDB), "mongo"),
+		Events:             instrumented.NewEventStore(mongo.NewEventStore(mongoDB), "mongo"),
+	}
+
+	// The bullet sweep finds rooms through this index
+	c.lifecycle.Append(Hook{Name: "room indexes", OnStart: mongoRoomRepo.EnsureIndexes})
+
+	metrics.RegisterActivity(mongoRoomRepo.CountActive, 15*time.Second)
+	metrics.RegisterConfigCache(func() map[string]metrics.CacheStats {
+		stats := map[string]metrics.CacheStats{}
+		for kind, s := range gameConfigCache.Stats() {
+			stats[kind] = metrics.CacheStats(s)
+		}
+		return stats
+	})
+
+	// Drop locally cached config when any instance changes it
+	c.addWorker("game config invalidations", gameConfigCache.ListenInvalidations)
+	return nil
+}
+
+// buildMemoryRepositories serves every port from the in-process store.
+func (c *Container) buildMemoryRepositories() error {
+	store, err := c.memoryStore()
+	if err != nil {
+		return err
+	}
+
+	c.Repositories = Repositories{
+		Rooms:              instrumented.NewRoomRepository(store.Rooms, "memory"),
+		Players:            instrumented.NewPlayerRepository(store.Players, "memory"),
+		Fish:               instrumented.NewFishRepository(store.Fish, "memory"),
+		Guns:               instrumented.NewGunRepository(store.Guns, "memory"),
+		RTP:                instrumented.NewRTPRepository(store.RTP, "memory"),
+		ShotResults:        instrumented.NewShotResultRepository(store.ShotResults, "memory"),
+		GameConfig:         instrumented.NewGameConfigRepository(store.GameConfig, "memory"),
+		GameConfigStore:    instrumented.NewGameConfigStore(store.GameConfig, "memory"),
+		GameConfigVersions: instrumented.NewGameConfigVersionStore(store.GameConfigVersions, "memory"),
+		GameConfigCache:    store.GameConfig,
+		WalletTransfers:    instrumented.NewWalletTransferRepository(store.WalletTransfers, "memory"),
+		FairSessions:       instrumented.NewFairSessionRepository(store.FairSessions, "memory"),
+		FairShots:          instrumented.NewFairShotRepository(store.FairShots, "memory"),
+		Events:             instrumented.NewEventStore(store.Events, "memory"),
+	}
+
+	metrics.RegisterActivity(store.Rooms.CountActive, 15*time.Second)
+	return nil
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// worker is a background loop that runs until its context is cancelled.
+type worker struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func (c *Container) addWorker(name string, run func(ctx context.Context) error) {
+	c.workers = append(c.workers, worker{name: name, run: run})
+}
+
+// workersHook starts every worker on its own goroutine and, on stop, cancels
+// them and waits until they return or ctx ends.
+func (c *Container) workersHook() Hook {
+	var (
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	)
+	return Hook{
+		Name: "workers",
+		OnStart: func(context.Context) error {
+			var background context.Context
+			background, cancel = context.WithCancel(context.Background())
+			for _, w := range c.workers {
+				w := w
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if err := w.run(background); err != nil && background.Err() == nil {
+						c.logger.Error("Background worker stopped", zap.String("worker", w.name), zap.Error(err))
+					}
+				}()
+			}
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+// reconcileWalletTransfers settles wallet transfers left pending by outages
+// or crashes every interval.
+func (c *Container) reconcileWalletTransfers(interval time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if interval <= 0 {
+			return nil
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+			settled, err := c.Usecases.Room.ReconcileWalletTransfers(ctx)
+			if err != nil {
+				c.logger.Error("Wallet reconciliation failed", zap.Error(err))
+				continue
+			}
+			if settled > 0 {
+				c.logger.Info("Reconciled wallet transfers", zap.Int("settled", settled))
+			}
+		}
+	}
+}
+
+// sweepExpiredBullets settles expired bullets in idle rooms every interval.
+func (c *Container) sweepExpiredBullets(interval time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if interval <= 0 {
+			return nil
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+			settled, err := c.Usecases.Shoot.SweepExpiredBullets(ctx)
+			if err != nil {
+				c.logger.Error("Bullet sweep failed", zap.Error(err))
+			}
+			if settled > 0 {
+				c.logger.Debug("Swept expired bullets", zap.Int("settled", settled))
+			}
+		}
+	}
+}
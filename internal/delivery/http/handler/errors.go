@@ -0,0 +1,16 @@
+package handler
+
+import (
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+// errorJSON writes err as the response body. Application errors carry
+// their apperr code, so clients can branch on it instead of the message.
+func errorJSON(c *fiber.Ctx, status int, err error) error {
+	body := fiber.Map{"error": err.Error()}
+	if code := apperr.CodeOf(err); code != "" {
+		body["code"] = code
+	}
+	return c.Status(status).JSON(body)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
+	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
+	"github.com/gofiber/fiber/v2"
+)
+
+type FairnessHandler struct {
+	fairnessUsecase *usecase.FairnessUsecase
+}
+
+func NewFairnessHandler(fairnessUsecase *usecase.FairnessUsecase) *FairnessHandler {
+	return &FairnessHandler{
+		fairnessUsecase: fairnessUsecase,
+	}
+}
+
+func (h *FairnessHandler) RegisterRoutes(app *fiber.App) {
+	fairnessAPI := app.Group("/api/v1/fairness")
+	fairnessAPI.Get("/rooms/:roomID", h.ActiveSession)
+	fairnessAPI.Get("/sessions/:sessionID", h.Session)
+	fairnessAPI.Get("/sessions/:sessionID/verify/:nonce", h.Verify)
+}
+
+func (h *FairnessHandler) ActiveSession(c *fiber.Ctx) error {
+	session, err := h.fairnessUsecase.ActiveSession(c.UserContext(), c.Params("roomID"), middleware.PlayerID(c))
+	if err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	return c.Status(200).JSON(session)
+}
+
+func (h *FairnessHandler) Session(c *fiber.Ctx) error {
+	session, err := h.fairnessUsecase.Session(c.UserContext(), c.Params("sessionID"))
+	if err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	return c.Status(200).JSON(session)
+}
+
+func (h *FairnessHandler) Verify(c *fiber.Ctx) error {
+	nonce, err := strconv.ParseUint(c.Params("nonce"), 10, 64)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request: nonce must be a non-negative integer"})
+	}
+
+	verification, err := h.fairnessUsecase.Verify(c.UserContext(), c.Params("sessionID"), nonce)
+	if err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	return c.Status(200).JSON(verification)
+}
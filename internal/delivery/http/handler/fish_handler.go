@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
 	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
 	fiber "github.com/gofiber/fiber/v2"
 )
@@ -17,7 +18,8 @@ func NewFishHandler(fishUsecase *usecase.FishUsecase) *FishHandler {
 
 func (h *FishHandler) RegisterRoutes(app *fiber.App) {
 	fishAPI := app.Group("/api/v1/fish")
-	fishAPI.Post("/:roomID/spawn", h.SpawnFish)
+	fishAPI.Post("/:roomID/spawn", middleware.RequireRole(middleware.RoleOperator), h.SpawnFish)
+	fishAPI.Post("/:roomID/:fishUID/escape", middleware.RequireRole(middleware.RoleOperator), h.EscapeFish)
 }
 
 func (h *FishHandler) SpawnFish(c *fiber.Ctx) error {
@@ -28,7 +30,7 @@ func (h *FishHandler) SpawnFish(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	if req.FishID <= 0 || req.FishUID == "" {
@@ -36,10 +38,18 @@ func (h *FishHandler) SpawnFish(c *fiber.Ctx) error {
 	}
 
 	roomID := c.Params("roomID")
-	fish, err := h.fishUsecase.SpawnFish(c.Context(), roomID, req.FishID, req.FishUID, req.PathID)
+	fish, err := h.fishUsecase.SpawnFish(c.UserContext(), roomID, req.FishID, req.FishUID, req.PathID)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	return c.Status(201).JSON(fish)
 }
+
+func (h *FishHandler) EscapeFish(c *fiber.Ctx) error {
+	if err := h.fishUsecase.EscapeFish(c.UserContext(), c.Params("roomID"), c.Params("fishUID")); err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	return c.Status(200).JSON(fiber.Map{"message": "fish escaped"})
+}
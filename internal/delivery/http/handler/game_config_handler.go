@@ -1,10 +1,26 @@
 package handler
 
 import (
+	"errors"
+	"strconv"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
 	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
 	"github.com/gofiber/fiber/v2"
 )
 
+// configKinds maps the route segment of each config document to its kind.
+var configKinds = map[string]string{
+	"bullets":    gameBaseModels.ConfigKindBullets,
+	"config":     gameBaseModels.ConfigKindConfigs,
+	"features":   gameBaseModels.ConfigKindFeatures,
+	"paths":      gameBaseModels.ConfigKindPaths,
+	"rtp":        gameBaseModels.ConfigKindRTPs,
+	"fish-types": gameBaseModels.ConfigKindFishTypes,
+}
+
 type GameConfigHandler struct {
 	gameConfigUsecase *usecase.GameConfigUsecase
 }
@@ -23,6 +39,16 @@ func (h *GameConfigHandler) RegisterRoutes(app *fiber.App) {
 	gameConfigAPI.Get("/:gameName/paths", h.GetGamePaths)
 	gameConfigAPI.Get("/:gameName/rtp", h.GetGameRTP)
 	gameConfigAPI.Get("/:gameName/fish-types", h.GetGameFishTypes)
+
+	operator := middleware.RequireRole(middleware.RoleOperator)
+	gameConfigAPI.Get("/:gameName/validate", operator, h.ValidateGame)
+	gameConfigAPI.Put("/:gameName/:kind", operator, h.PutDocument)
+	gameConfigAPI.Patch("/:gameName/:kind", operator, h.PatchDocument)
+	gameConfigAPI.Get("/:gameName/:kind/versions", operator, h.ListVersions)
+	gameConfigAPI.Get("/:gameName/:kind/versions/:version", operator, h.GetVersion)
+	gameConfigAPI.Get("/:gameName/:kind/rollout", operator, h.GetRollout)
+	gameConfigAPI.Post("/:gameName/:kind/promote", operator, h.Promote)
+	gameConfigAPI.Post("/:gameName/:kind/rollback", operator, h.Rollback)
 }
 
 func (h *GameConfigHandler) GetBulletConfig(c *fiber.Ctx) error {
@@ -31,9 +57,9 @@ func (h *GameConfigHandler) GetBulletConfig(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "game_name is required"})
 	}
 
-	config, err := h.gameConfigUsecase.GetBulletConfig(c.Context(), gameName)
+	config, err := h.gameConfigUsecase.GetBulletConfig(c.UserContext(), gameName)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 404, err)
 	}
 
 	return c.Status(200).JSON(config)
@@ -45,9 +71,9 @@ func (h *GameConfigHandler) GetGameConfig(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "game_name is required"})
 	}
 
-	config, err := h.gameConfigUsecase.GetGameConfig(c.Context(), gameName)
+	config, err := h.gameConfigUsecase.GetGameConfig(c.UserContext(), gameName)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 404, err)
 	}
 
 	return c.Status(200).JSON(config)
@@ -59,9 +85,9 @@ func (h *GameConfigHandler) GetGameFeatures(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "game_name is required"})
 	}
 
-	features, err := h.gameConfigUsecase.GetGameFeatures(c.Context(), gameName)
+	features, err := h.gameConfigUsecase.GetGameFeatures(c.UserContext(), gameName)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 404, err)
 	}
 
 	return c.Status(200).JSON(features)
@@ -73,9 +99,9 @@ func (h *GameConfigHandler) GetGamePaths(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "game_name is required"})
 	}
 
-	paths, err := h.gameConfigUsecase.GetGamePaths(c.Context(), gameName)
+	paths, err := h.gameConfigUsecase.GetGamePaths(c.UserContext(), gameName)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 404, err)
 	}
 
 	return c.Status(200).JSON(paths)
@@ -87,9 +113,9 @@ func (h *GameConfigHandler) GetGameRTP(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "game_name is required"})
 	}
 
-	rtp, err := h.gameConfigUsecase.GetGameRTP(c.Context(), gameName)
+	rtp, err := h.gameConfigUsecase.GetGameRTP(c.UserContext(), gameName)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 404, err)
 	}
 
 	return c.Status(200).JSON(rtp)
@@ -101,10 +127,153 @@ func (h *GameConfigHandler) GetGameFishTypes(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "game_name is required"})
 	}
 
-	fishTypes, err := h.gameConfigUsecase.GetGameFishTypes(c.Context(), gameName)
+	fishTypes, err := h.gameConfigUsecase.GetGameFishTypes(c.UserContext(), gameName)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 404, err)
 	}
 
 	return c.Status(200).JSON(fishTypes)
 }
+
+func (h *GameConfigHandler) PutDocument(c *fiber.Ctx) error {
+	kind, ok := configKinds[c.Params("kind")]
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "unknown config kind"})
+	}
+
+	doc, err := h.gameConfigUsecase.PutDocument(c.UserContext(), kind, c.Params("gameName"), middleware.PlayerID(c), c.QueryInt("rollout", 100), c.Body())
+	if err != nil {
+		return configWriteError(c, err)
+	}
+
+	return c.Status(200).JSON(doc)
+}
+
+func (h *GameConfigHandler) PatchDocument(c *fiber.Ctx) error {
+	kind, ok := configKinds[c.Params("kind")]
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "unknown config kind"})
+	}
+
+	doc, err := h.gameConfigUsecase.PatchDocument(c.UserContext(), kind, c.Params("gameName"), middleware.PlayerID(c), c.QueryInt("rollout", 100), c.Body())
+	if err != nil {
+		return configWriteError(c, err)
+	}
+
+	return c.Status(200).JSON(doc)
+}
+
+func (h *GameConfigHandler) ListVersions(c *fiber.Ctx) error {
+	kind, ok := configKinds[c.Params("kind")]
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "unknown config kind"})
+	}
+
+	versions, err := h.gameConfigUsecase.ListVersions(c.UserContext(), kind, c.Params("gameName"), c.QueryInt("limit", 20))
+	if err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	return c.Status(200).JSON(versions)
+}
+
+func (h *GameConfigHandler) GetVersion(c *fiber.Ctx) error {
+	kind, ok := configKinds[c.Params("kind")]
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "unknown config kind"})
+	}
+	version, err := strconv.ParseInt(c.Params("version"), 10, 64)
+	if err != nil || version <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid version"})
+	}
+
+	v, err := h.gameConfigUsecase.GetVersion(c.UserContext(), kind, c.Params("gameName"), version)
+	if err != nil {
+		return errorJSON(c, 404, err)
+	}
+
+	return c.Status(200).JSON(v)
+}
+
+func (h *GameConfigHandler) GetRollout(c *fiber.Ctx) error {
+	kind, ok := configKinds[c.Params("kind")]
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "unknown config kind"})
+	}
+
+	rollout, err := h.gameConfigUsecase.GetRollout(c.UserContext(), kind, c.Params("gameName"))
+	if err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	return c.Status(200).JSON(rollout)
+}
+
+func (h *GameConfigHandler) Promote(c *fiber.Ctx) error {
+	kind, ok := configKinds[c.Params("kind")]
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "unknown config kind"})
+	}
+
+	var req struct {
+		Version int64 `json:"version"`
+		Percent int   `json:"percent"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	rollout, err := h.gameConfigUsecase.Promote(c.UserContext(), kind, c.Params("gameName"), req.Version, req.Percent, middleware.PlayerID(c))
+	if err != nil {
+		return configWriteError(c, err)
+	}
+
+	return c.Status(200).JSON(rollout)
+}
+
+func (h *GameConfigHandler) Rollback(c *fiber.Ctx) error {
+	kind, ok := configKinds[c.Params("kind")]
+	if !ok {
+		return c.Status(404).JSON(fiber.Map{"error": "unknown config kind"})
+	}
+
+	var req struct {
+		Version int64 `json:"version"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	rollout, err := h.gameConfigUsecase.Rollback(c.UserContext(), kind, c.Params("gameName"), req.Version, middleware.PlayerID(c))
+	if err != nil {
+		return configWriteError(c, err)
+	}
+
+	return c.Status(200).JSON(rollout)
+}
+
+// ValidateGame reports every violation in the stable config of a game,
+// including references between documents. An empty list means the config
+// is consistent.
+func (h *GameConfigHandler) ValidateGame(c *fiber.Ctx) error {
+	violations, err := h.gameConfigUsecase.ValidateGame(c.UserContext(), c.Params("gameName"))
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "no config found for game"})
+		}
+		return errorJSON(c, 400, err)
+	}
+	if violations == nil {
+		violations = []gameBaseModels.Violation{}
+	}
+
+	return c.Status(200).JSON(fiber.Map{"valid": len(violations) == 0, "violations": violations})
+}
+
+func configWriteError(c *fiber.Ctx, err error) error {
+	var validationErr *gameBaseModels.ValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(422).JSON(fiber.Map{"error": err.Error(), "code": apperr.CodeOf(err), "violations": validationErr.Violations})
+	}
+	return errorJSON(c, 400, err)
+}
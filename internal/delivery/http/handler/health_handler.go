@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/health"
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{
+		checker: checker,
+	}
+}
+
+// RegisterRoutes mounts the probes at the root, outside /api/v1, so they
+// need no token.
+func (h *HealthHandler) RegisterRoutes(app *fiber.App) {
+	app.Get("/livez", h.Live)
+	app.Get("/health", h.Live)
+	app.Get("/readyz", h.Ready)
+}
+
+// Live reports that the process is up and serving. It checks no
+// dependencies, so an outage of Mongo or Redis does not get the server
+// restarted.
+func (h *HealthHandler) Live(c *fiber.Ctx) error {
+	return c.Status(200).JSON(fiber.Map{"status": "ok"})
+}
+
+// Ready reports whether the server should receive traffic, with the status
+// and latency of each dependency. It answers 503 while any dependency is
+// down and once shutdown has begun.
+func (h *HealthHandler) Ready(c *fiber.Ctx) error {
+	report := h.checker.Readiness(c.UserContext())
+	if !report.Ready() {
+		return c.Status(503).JSON(report)
+	}
+	return c.Status(200).JSON(report)
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"errors"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
 	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
 	fiber "github.com/gofiber/fiber/v2"
 )
 
@@ -25,21 +29,23 @@ func (h *RoomHandler) RegisterRoutes(app *fiber.App) {
 
 func (h *RoomHandler) CreateRoom(c *fiber.Ctx) error {
 	var req struct {
-		RoomID     string `json:"room_id"`
-		MaxPlayers int    `json:"max_players"`
+		RoomID       string `json:"room_id"`
+		GameName     string `json:"game_name"`
+		MaxPlayers   int    `json:"max_players"`
+		ProvablyFair bool   `json:"provably_fair"`
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	if req.RoomID == "" || req.MaxPlayers <= 0 {
 		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
 	}
 
-	room, err := h.roomUsecase.CreateRoom(c.Context(), req.RoomID, req.MaxPlayers)
+	room, err := h.roomUsecase.CreateRoom(c.UserContext(), req.RoomID, req.GameName, req.MaxPlayers, req.ProvablyFair)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	return c.Status(201).JSON(room)
@@ -47,37 +53,32 @@ func (h *RoomHandler) CreateRoom(c *fiber.Ctx) error {
 
 func (h *RoomHandler) JoinRoom(c *fiber.Ctx) error {
 	var req struct {
-		PlayerID       string `json:"player_id"`
-		SeatID         int    `json:"seat_id"`
-		InitialBalance int64  `json:"initial_balance"`
+		SeatID     int    `json:"seat_id"`
+		BuyIn      int64  `json:"buy_in"`
+		ClientSeed string `json:"client_seed"`
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	roomID := c.Params("roomID")
-	room, player, err := h.roomUsecase.JoinRoom(c.Context(), roomID, req.PlayerID, req.SeatID, req.InitialBalance)
+	room, player, err := h.roomUsecase.JoinRoom(c.UserContext(), roomID, middleware.PlayerID(c), req.SeatID, req.BuyIn, req.ClientSeed)
+	if errors.Is(err, apperr.ErrShuttingDown) {
+		return errorJSON(c, 503, err)
+	}
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{"room": room, "player": player})
 }
 
 func (h *RoomHandler) LeaveRoom(c *fiber.Ctx) error {
-	var req struct {
-		PlayerID string `json:"player_id"`
-	}
-
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
-	}
-
 	roomID := c.Params("roomID")
-	room, player, err := h.roomUsecase.LeaveRoom(c.Context(), roomID, req.PlayerID)
+	room, player, err := h.roomUsecase.LeaveRoom(c.UserContext(), roomID, middleware.PlayerID(c))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{"room": room, "player": player})
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
 	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
 	"github.com/gofiber/fiber/v2"
 )
@@ -18,7 +19,7 @@ func NewRTPHandler(rtpUsecase *usecase.RTPUsecase) *RTPHandler {
 func (h *RTPHandler) RegisterRoutes(app *fiber.App) {
 	rtpAPI := app.Group("/api/v1/rtp")
 	rtpAPI.Get("/:roomID", h.GetRTPState)
-	rtpAPI.Post("/:roomID/update", h.UpdateRTPState)
+	rtpAPI.Post("/:roomID/update", middleware.RequireRole(middleware.RoleOperator), h.UpdateRTPState)
 }
 
 func (h *RTPHandler) GetRTPState(c *fiber.Ctx) error {
@@ -27,9 +28,9 @@ func (h *RTPHandler) GetRTPState(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "room_id is required"})
 	}
 
-	state, err := h.rtpUsecase.GetState(c.Context(), roomID)
+	state, err := h.rtpUsecase.GetState(c.UserContext(), roomID)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	return c.Status(200).JSON(state)
@@ -42,7 +43,7 @@ func (h *RTPHandler) UpdateRTPState(c *fiber.Ctx) error {
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	roomID := c.Params("roomID")
@@ -54,9 +55,9 @@ func (h *RTPHandler) UpdateRTPState(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "total_bet_delta and total_win_delta must be non-negative"})
 	}
 
-	state, err := h.rtpUsecase.Add(c.Context(), roomID, req.TotalBetDelta, req.TotalWinDelta)
+	state, err := h.rtpUsecase.Add(c.UserContext(), roomID, req.TotalBetDelta, req.TotalWinDelta)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	return c.Status(200).JSON(state)
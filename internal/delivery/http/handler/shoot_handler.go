@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
 	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
 	"github.com/gofiber/fiber/v2"
 )
@@ -18,31 +19,73 @@ func NewShootHandler(shootUsecase *usecase.ShootUsecase) *ShootHandler {
 func (h *ShootHandler) RegisterRoutes(app *fiber.App) {
 	shootAPI := app.Group("/api/v1/shoot")
 	shootAPI.Post("/fire", h.Fire)
+	shootAPI.Post("/hit", h.Hit)
+	shootAPI.Post("/gun", h.ChangeGun)
 }
 
 func (h *ShootHandler) Fire(c *fiber.Ctx) error {
 	var req struct {
 		RoomID   string `json:"room_id"`
-		PlayerID string `json:"player_id"`
+		BulletID string `json:"bullet_id"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	if req.RoomID == "" || req.BulletID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request: room_id and bullet_id are required"})
+	}
+
+	result, err := h.shootUsecase.Fire(c.UserContext(), req.RoomID, middleware.PlayerID(c), req.BulletID)
+	if err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	return c.Status(200).JSON(result)
+}
+
+func (h *ShootHandler) Hit(c *fiber.Ctx) error {
+	var req struct {
+		RoomID   string `json:"room_id"`
+		BulletID string `json:"bullet_id"`
 		FishUID  string `json:"fish_uid"`
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
+	}
+
+	if req.RoomID == "" || req.BulletID == "" || req.FishUID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request: room_id, bullet_id, and fish_uid are required"})
+	}
+
+	result, err := h.shootUsecase.Hit(c.UserContext(), req.RoomID, middleware.PlayerID(c), req.BulletID, req.FishUID)
+	if err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	return c.Status(200).JSON(result)
+}
+
+func (h *ShootHandler) ChangeGun(c *fiber.Ctx) error {
+	var req struct {
+		RoomID string `json:"room_id"`
+		GunID  int    `json:"gun_id"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return errorJSON(c, 400, err)
 	}
 
-	if req.RoomID == "" || req.PlayerID == "" || req.FishUID == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request: room_id, player_id, and fish_uid are required"})
+	if req.RoomID == "" || req.GunID <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request: room_id and gun_id are required"})
 	}
 
-	shot, fish, player, err := h.shootUsecase.Fire(c.Context(), req.RoomID, req.PlayerID, req.FishUID)
+	player, err := h.shootUsecase.ChangeGun(c.UserContext(), req.RoomID, middleware.PlayerID(c), req.GunID)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
-	return c.Status(200).JSON(fiber.Map{
-		"shot":   shot,
-		"fish":   fish,
-		"player": player,
-	})
+	return c.Status(200).JSON(player)
 }
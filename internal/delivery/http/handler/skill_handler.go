@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
 	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
 	"github.com/gofiber/fiber/v2"
@@ -23,18 +24,17 @@ func (h *SkillHandler) RegisterRoutes(app *fiber.App) {
 
 func (h *SkillHandler) UseSkill(c *fiber.Ctx) error {
 	var req struct {
-		PlayerID   string `json:"player_id"`
 		SkillType  string `json:"skill_type"`
 		Cost       int    `json:"cost"`
 		CooldownMs int    `json:"cooldown_ms"`
 	}
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
-	if req.PlayerID == "" || req.SkillType == "" || req.Cost <= 0 {
-		return c.Status(400).JSON(fiber.Map{"error": "invalid request: player_id, skill_type, and cost are required and cost must be positive"})
+	if req.SkillType == "" || req.Cost <= 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request: skill_type and cost are required and cost must be positive"})
 	}
 
 	skill := &entity.Skill{
@@ -43,9 +43,9 @@ func (h *SkillHandler) UseSkill(c *fiber.Ctx) error {
 		CooldownMs: req.CooldownMs,
 	}
 
-	err := h.skillUsecase.UseSkill(c.Context(), req.PlayerID, skill)
+	err := h.skillUsecase.UseSkill(c.UserContext(), middleware.PlayerID(c), skill)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return errorJSON(c, 400, err)
 	}
 
 	return c.Status(200).JSON(fiber.Map{
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
+	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+type SyncHandler struct {
+	syncUsecase *usecase.SyncUsecase
+}
+
+func NewSyncHandler(syncUsecase *usecase.SyncUsecase) *SyncHandler {
+	return &SyncHandler{
+		syncUsecase: syncUsecase,
+	}
+}
+
+func (h *SyncHandler) RegisterRoutes(app *fiber.App) {
+	syncAPI := app.Group("/api/v1/sync")
+	syncAPI.Get("/:roomID", h.Resync)
+}
+
+func (h *SyncHandler) Resync(c *fiber.Ctx) error {
+	roomID := c.Params("roomID")
+	if roomID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "room_id is required"})
+	}
+
+	snapshot, err := h.syncUsecase.Snapshot(c.UserContext(), roomID, middleware.PlayerID(c))
+	if err != nil {
+		return errorJSON(c, 400, err)
+	}
+
+	return c.Status(200).JSON(snapshot)
+}
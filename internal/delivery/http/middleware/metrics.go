@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/metrics"
+	fiber "github.com/gofiber/fiber/v2"
+)
+
+// Metrics records the latency of every request by method, matched route
+// pattern and status. Routing by pattern rather than path keeps ids out of
+// the labels.
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// The app's error handler writes the response after this
+			// middleware returns, so take the status from the error.
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		metrics.HTTPRequestDuration.
+			WithLabelValues(c.Method(), c.Route().Path, strconv.Itoa(status)).
+			Observe(time.Since(start).Seconds())
+		return err
+	}
+}
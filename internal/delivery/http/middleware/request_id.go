@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	// HeaderRequestID carries the request id in both directions.
+	HeaderRequestID = "X-Request-ID"
+	// LocalsRequestID is the fiber.Ctx locals key the request id is stored
+	// under.
+	LocalsRequestID = "request_id"
+
+	maxRequestIDLength = 128
+)
+
+// RequestID tags every request with an id: the caller's X-Request-ID when it
+// sends a usable one, a random one otherwise. The id is echoed in the
+// response and added to the logger carried in c.UserContext(), so every log
+// line of the request can be found from it.
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(HeaderRequestID)
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+
+		c.Set(HeaderRequestID, id)
+		c.Locals(LocalsRequestID, id)
+		c.SetUserContext(logger.With(c.UserContext(), zap.String("request_id", id)))
+		return c.Next()
+	}
+}
+
+// isValidRequestID accepts ids of letters, digits and "-_.:" only, so a
+// caller cannot inject arbitrary text into the logs.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-' || c == '_' || c == '.' || c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unavailable"
+	}
+	return hex.EncodeToString(b)
+}
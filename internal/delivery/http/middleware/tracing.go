@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	fiber "github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var tracer = otel.Tracer("github.com/BT2701/backend-fishing-gameplay/internal/delivery/http")
+
+// Tracing starts a server span for every request, continuing the trace in
+// the caller's traceparent header when there is one. The span is carried in
+// c.UserContext(), which handlers pass on to usecases.
+func Tracing() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		headers := propagation.MapCarrier{}
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			headers[string(key)] = string(value)
+		})
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headers)
+
+		ctx, span := tracer.Start(ctx, c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+			),
+		)
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		// The route is only known once the router has matched it.
+		route := c.Route().Path
+		status := c.Response().StatusCode()
+		if fiberErr, ok := err.(*fiber.Error); ok {
+			status = fiberErr.Code
+		} else if err != nil {
+			status = fiber.StatusInternalServerError
+		}
+		span.SetName(fmt.Sprintf("%s %s", c.Method(), route))
+		span.SetAttributes(
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		)
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, fmt.Sprintf("status %d", status))
+			logger.FromContext(ctx).Error("Request failed",
+				zap.String("method", c.Method()),
+				zap.String("route", route),
+				zap.Int("status", status),
+				zap.Error(err),
+			)
+		}
+		return err
+	}
+}
@@ -2,8 +2,14 @@ package http
 
 import (
 	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/handler"
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/ws"
+	ws_handler "github.com/BT2701/backend-fishing-gameplay/internal/delivery/ws/handler"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/health"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/metrics"
 	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
 	fiber "github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 )
 
 func SetupRoutes(
@@ -14,13 +20,34 @@ func SetupRoutes(
 	rtpUsecase *usecase.RTPUsecase,
 	skillUsecase *usecase.SkillUsecase,
 	gameConfigUsecase *usecase.GameConfigUsecase,
+	syncUsecase *usecase.SyncUsecase,
+	fairnessUsecase *usecase.FairnessUsecase,
+	hub *ws.Hub,
+	checker *health.Checker,
+	jwtSecret string,
 ) {
+	app.Use(middleware.Metrics())
+	app.Get("/metrics", adaptor.HTTPHandler(metrics.Handler()))
+	// Probes are registered ahead of request ids and tracing so frequent
+	// polling does not fill the logs and traces.
+	handler.NewHealthHandler(checker).RegisterRoutes(app)
+	app.Use(middleware.RequestID())
+	app.Use(middleware.Tracing())
+
+	// Every API and websocket route requires a signed access token; the
+	// player id used by handlers always comes from the token.
+	app.Use("/api/v1", middleware.Auth([]byte(jwtSecret)))
+	app.Use("/ws", middleware.WebSocketAuth([]byte(jwtSecret)))
+
 	roomHandler := handler.NewRoomHandler(roomUsecase)
 	fishHandler := handler.NewFishHandler(fishUsecase)
 	shootHandler := handler.NewShootHandler(shootUsecase)
 	rtpHandler := handler.NewRTPHandler(rtpUsecase)
 	skillHandler := handler.NewSkillHandler(skillUsecase)
 	gameConfigHandler := handler.NewGameConfigHandler(gameConfigUsecase)
+	syncHandler := handler.NewSyncHandler(syncUsecase)
+	fairnessHandler := handler.NewFairnessHandler(fairnessUsecase)
+	roomWSHandler := ws_handler.NewRoomWSHandler(syncUsecase, hub)
 
 	roomHandler.RegisterRoutes(app)
 	fishHandler.RegisterRoutes(app)
@@ -28,8 +55,7 @@ func SetupRoutes(
 	rtpHandler.RegisterRoutes(app)
 	skillHandler.RegisterRoutes(app)
 	gameConfigHandler.RegisterRoutes(app)
-
-	app.Get("/health", func(c *fiber.Ctx) error {
-		return c.Status(200).JSON(fiber.Map{"status": "ok"})
-	})
+	syncHandler.RegisterRoutes(app)
+	fairnessHandler.RegisterRoutes(app)
+	roomWSHandler.RegisterRoutes(app)
 }
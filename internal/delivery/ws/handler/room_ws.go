@@ -1 +1,147 @@
-package ws_handler
\ No newline at end of file
+package ws_handler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/http/middleware"
+	"github.com/BT2701/backend-fishing-gameplay/internal/delivery/ws"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var tracer = otel.Tracer("github.com/BT2701/backend-fishing-gameplay/internal/delivery/ws")
+
+type RoomWSHandler struct {
+	syncUsecase *usecase.SyncUsecase
+	hub         *ws.Hub
+}
+
+func NewRoomWSHandler(syncUsecase *usecase.SyncUsecase, hub *ws.Hub) *RoomWSHandler {
+	return &RoomWSHandler{
+		syncUsecase: syncUsecase,
+		hub:         hub,
+	}
+}
+
+func (h *RoomWSHandler) RegisterRoutes(app *fiber.App) {
+	wsAPI := app.Group("/ws")
+	wsAPI.Use(func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return fiber.ErrUpgradeRequired
+		}
+		return c.Next()
+	})
+	wsAPI.Get("/rooms/:roomID", websocket.New(h.Serve))
+}
+
+// Serve binds a connection to a room seat and pushes a snapshot straight
+// away, so a reconnecting client is in sync before it sees any broadcast.
+// The seat is checked before the client is registered, so a player who is
+// not seated never receives the room's broadcasts.
+func (h *RoomWSHandler) Serve(conn *websocket.Conn) {
+	claims, _ := conn.Locals(middleware.LocalsClaims).(*middleware.Claims)
+	if claims == nil {
+		return
+	}
+
+	client := ws.NewClient(conn, conn.Params("roomID"), claims.PlayerID)
+	if _, err := h.snapshot(client); err != nil {
+		if apperr.CodeOf(err) == "" {
+			closeConn(conn, websocket.CloseInternalServerErr, "room state unavailable")
+		} else {
+			closeConn(conn, websocket.ClosePolicyViolation, err.Error())
+		}
+		return
+	}
+	if !h.hub.Register(client) {
+		closeConn(conn, websocket.CloseTryAgainLater, "server shutting down")
+		return
+	}
+	defer func() {
+		h.hub.Unregister(client)
+		// The connection is recycled once Serve returns, so the write pump
+		// has to be done with it first.
+		<-client.Done()
+	}()
+	go client.WritePump()
+
+	// Taken again now that the client is registered, so no broadcast falls
+	// between the snapshot and the first one the client receives.
+	h.sendSnapshot(client)
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg ws.Message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			h.hub.Send(client, ws.Message{Type: ws.MessageTypeError, Error: "invalid message"})
+			continue
+		}
+
+		switch msg.Type {
+		case ws.MessageTypeResync:
+			h.sendSnapshot(client)
+		default:
+			h.hub.Send(client, ws.Message{Type: ws.MessageTypeError, Error: "unknown message type"})
+		}
+	}
+}
+
+func (h *RoomWSHandler) sendSnapshot(client *ws.Client) {
+	snapshot, err := h.snapshot(client)
+	if err != nil {
+		h.hub.Send(client, ws.Message{
+			Type:  ws.MessageTypeError,
+			Error: err.Error(),
+			Code:  string(apperr.CodeOf(err)),
+		})
+		return
+	}
+	h.hub.Send(client, ws.Message{
+		Type:       ws.MessageTypeSnapshot,
+		Seq:        snapshot.Seq,
+		ServerTime: snapshot.ServerTime,
+		Data:       snapshot,
+	})
+}
+
+func (h *RoomWSHandler) snapshot(client *ws.Client) (*entity.RoomSnapshot, error) {
+	// Each message starts its own trace: the upgrade request's span ended
+	// when the connection was handed over.
+	ctx, span := tracer.Start(context.Background(), "WS snapshot",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("room_id", client.RoomID),
+			attribute.String("player_id", client.PlayerID),
+		),
+	)
+	defer span.End()
+	ctx = logger.With(ctx, zap.String("room_id", client.RoomID), zap.String("player_id", client.PlayerID))
+
+	snapshot, err := h.syncUsecase.Snapshot(ctx, client.RoomID, client.PlayerID)
+	if err != nil && apperr.CodeOf(err) == "" {
+		logger.FromContext(ctx).Error("Snapshot failed", zap.Error(err))
+	}
+	return snapshot, err
+}
+
+// closeConn closes a connection that was never registered with the hub,
+// telling the client why.
+func closeConn(conn *websocket.Conn, code int, reason string) {
+	_ = conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(time.Second))
+}
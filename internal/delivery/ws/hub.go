@@ -1 +1,266 @@
-package ws
\ No newline at end of file
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/metrics"
+	"github.com/gofiber/websocket/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	MessageTypeResync   = "resync"
+	MessageTypeSnapshot = "snapshot"
+	MessageTypeEvents   = "events"
+	MessageTypeError    = "error"
+	MessageTypeShutdown = "shutdown"
+
+	sendBufferSize = 64
+	writeTimeout   = 5 * time.Second
+
+	shutdownPollInterval = 50 * time.Millisecond
+)
+
+// Message is the envelope for every frame exchanged over a room socket.
+// Seq is the room sequence the payload reflects; clients drop any broadcast
+// whose Seq is not greater than the last one they applied.
+type Message struct {
+	Type       string      `json:"type"`
+	Seq        int64       `json:"seq,omitempty"`
+	ServerTime int64       `json:"server_time"`
+	Data       interface{} `json:"data,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	Code       string      `json:"code,omitempty"`
+}
+
+// Client is a single websocket connection bound to a room seat.
+type Client struct {
+	RoomID   string
+	PlayerID string
+
+	conn      *websocket.Conn
+	send      chan []byte
+	closing   chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func NewClient(conn *websocket.Conn, roomID, playerID string) *Client {
+	return &Client{
+		RoomID:   roomID,
+		PlayerID: playerID,
+		conn:     conn,
+		send:     make(chan []byte, sendBufferSize),
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// WritePump drains the send queue onto the connection. It returns when the
+// queue is closed or a write fails, or closes the connection itself once
+// asked to by closeAfterSend.
+func (c *Client) WritePump() {
+	defer close(c.done)
+	for {
+		select {
+		case data, ok := <-c.send:
+			if !ok || !c.write(data) {
+				return
+			}
+		case <-c.closing:
+			// Flush what is already queued, such as the shutdown notice.
+			c.flushQueued()
+			_ = c.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+				time.Now().Add(writeTimeout))
+			// Closing the socket ends the read loop, which unregisters us.
+			_ = c.conn.Close()
+			return
+		}
+	}
+}
+
+// flushQueued writes the messages already queued without waiting for more.
+func (c *Client) flushQueued() {
+	for {
+		select {
+		case data, ok := <-c.send:
+			if !ok || !c.write(data) {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (c *Client) write(data []byte) bool {
+	_ = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return c.conn.WriteMessage(websocket.TextMessage, data) == nil
+}
+
+// Done is closed once WritePump has returned and no longer uses the
+// connection.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// closeAfterSend asks WritePump to close the connection once the queued
+// messages are written.
+func (c *Client) closeAfterSend() {
+	c.closeOnce.Do(func() { close(c.closing) })
+}
+
+// Hub tracks connected clients per room and fans out broadcasts.
+type Hub struct {
+	mu       sync.RWMutex
+	rooms    map[string]map[*Client]struct{}
+	shutdown bool
+	now      func() time.Time
+	logger   *zap.Logger
+}
+
+func NewHub(logger *zap.Logger) *Hub {
+	return &Hub{
+		rooms:  map[string]map[*Client]struct{}{},
+		now:    time.Now,
+		logger: logger,
+	}
+}
+
+// Register adds a client to its room. It returns false once the hub is
+// shutting down; the caller should then close the connection.
+func (h *Hub) Register(c *Client) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.shutdown {
+		return false
+	}
+	clients, ok := h.rooms[c.RoomID]
+	if !ok {
+		clients = map[*Client]struct{}{}
+		h.rooms[c.RoomID] = clients
+	}
+	clients[c] = struct{}{}
+	metrics.WSConnections.Inc()
+	return true
+}
+
+func (h *Hub) Unregister(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if clients, ok := h.rooms[c.RoomID]; ok {
+		if _, registered := clients[c]; registered {
+			delete(clients, c)
+			metrics.WSConnections.Dec()
+		}
+		if len(clients) == 0 {
+			delete(h.rooms, c.RoomID)
+		}
+	}
+	close(c.send)
+}
+
+// Send queues a message for a single client.
+func (h *Hub) Send(c *Client, msg Message) {
+	data, err := h.encode(msg)
+	if err != nil {
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if _, ok := h.rooms[c.RoomID][c]; ok {
+		h.enqueue(c, data)
+	}
+}
+
+// Broadcast queues a message for every client in a room. Clients whose
+// queue is full miss the frame and are expected to resync on the seq gap.
+func (h *Hub) Broadcast(roomID string, msg Message) {
+	data, err := h.encode(msg)
+	if err != nil {
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.rooms[roomID] {
+		h.enqueue(c, data)
+	}
+}
+
+// Publish broadcasts the events of a saved room mutation, tagged with the
+// room sequence it was saved under. It implements port.RoomPublisher.
+func (h *Hub) Publish(roomID string, seq int64, events []*entity.GameEvent) {
+	h.Broadcast(roomID, Message{Type: MessageTypeEvents, Seq: seq, Data: events})
+}
+
+// Shutdown sends msg to every connected client, closes each connection once
+// its queue is written and refuses new ones. It returns when every client
+// has unregistered, or with ctx's error if ctx ends first.
+func (h *Hub) Shutdown(ctx context.Context, msg Message) error {
+	data, err := h.encode(msg)
+
+	h.mu.Lock()
+	h.shutdown = true
+	for _, clients := range h.rooms {
+		for c := range clients {
+			if err == nil {
+				h.enqueue(c, data)
+			}
+			c.closeAfterSend()
+		}
+	}
+	h.mu.Unlock()
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+	for h.connected() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func (h *Hub) connected() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	n := 0
+	for _, clients := range h.rooms {
+		n += len(clients)
+	}
+	return n
+}
+
+func (h *Hub) encode(msg Message) ([]byte, error) {
+	if msg.ServerTime == 0 {
+		msg.ServerTime = h.now().UnixMilli()
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		h.logger.Error("Failed to encode websocket message", zap.String("type", msg.Type), zap.Error(err))
+		return nil, err
+	}
+	return data, nil
+}
+
+// enqueue must be called with h.mu held so it cannot race with Unregister
+// closing the queue.
+func (h *Hub) enqueue(c *Client, data []byte) {
+	select {
+	case c.send <- data:
+	default:
+		h.logger.Warn("Dropping websocket message for slow client",
+			zap.String("room_id", c.RoomID),
+			zap.String("player_id", c.PlayerID),
+		)
+	}
+}
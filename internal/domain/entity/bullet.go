@@ -8,4 +8,67 @@ type (
 		GunID    int    `json:"gun_id" bson:"gun_id"`
 		FireTime int64  `json:"fire_time" bson:"fire_time"`
 	}
+
+	// Bullet is a charged shot that has left the cannon but has not yet been
+	// resolved against a fish. Times are unix milliseconds.
+	Bullet struct {
+		BulletID  string `json:"bullet_id" bson:"bullet_id"`
+		PlayerID  string `json:"player_id" bson:"player_id"`
+		GunID     int    `json:"gun_id" bson:"gun_id"`
+		Cost      int64  `json:"cost" bson:"cost"`
+		Damage    int    `json:"damage" bson:"damage"`
+		FiredAt   int64  `json:"fired_at" bson:"fired_at"`
+		ExpiresAt int64  `json:"expires_at" bson:"expires_at"`
+	}
+
+	// ShotResult is the outcome of either phase of a shot. It is stored
+	// against the client bullet id so a retried request gets the original
+	// result back.
+	ShotResult struct {
+		Shot     Shot          `json:"shot"`
+		RoomID   string        `json:"room_id"`
+		Bullet   *Bullet       `json:"bullet,omitempty"`
+		Fish     *FishInstance `json:"fish,omitempty"`
+		Player   *Player       `json:"player"`
+		Cost     int64         `json:"cost"`
+		Hit      bool          `json:"hit"`
+		Reward   int64         `json:"reward"`
+		Draw     *RNGDraw      `json:"draw,omitempty"`
+		Replayed bool          `json:"replayed"`
+	}
 )
+
+const MaxBulletIDLength = 64
+
+// IsValidBulletID accepts ids of up to MaxBulletIDLength letters, digits,
+// '-' and '_'. The charset keeps ids safe to use in storage keys.
+func IsValidBulletID(id string) bool {
+	if id == "" || len(id) > MaxBulletIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// BulletKey scopes a client bullet id to its shooter within Room.Bullets.
+func BulletKey(playerID, bulletID string) string {
+	return playerID + ":" + bulletID
+}
+
+func (b *Bullet) IsExpired(nowMs int64) bool {
+	return nowMs >= b.ExpiresAt
+}
+
+// RNGDraw identifies the random draw behind an outcome. Together with the
+// logged seed for SeedID it is enough to recompute Value offline.
+type RNGDraw struct {
+	SeedID string  `json:"seed_id" bson:"seed_id"`
+	Index  uint64  `json:"index" bson:"index"`
+	Value  float64 `json:"value" bson:"value"`
+}
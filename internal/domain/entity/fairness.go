@@ -0,0 +1,67 @@
+package entity
+
+type (
+	// FairSession is a player's provably-fair commitment for one stay in a
+	// room. ServerSeedHash is published when the session starts; ServerSeed
+	// is only disclosed once RevealedAt is set. Nonce is the next nonce to
+	// be used; while the session is active it is kept on the player's seat
+	// and it is recorded here when the session is revealed. Times are unix
+	// seconds.
+	FairSession struct {
+		SessionID      string `json:"session_id" bson:"session_id"`
+		RoomID         string `json:"room_id" bson:"room_id"`
+		PlayerID       string `json:"player_id" bson:"player_id"`
+		ServerSeed     string `json:"server_seed,omitempty" bson:"server_seed"`
+		ServerSeedHash string `json:"server_seed_hash" bson:"server_seed_hash"`
+		ClientSeed     string `json:"client_seed" bson:"client_seed"`
+		Nonce          uint64 `json:"nonce" bson:"nonce"`
+		CreatedAt      int64  `json:"created_at" bson:"created_at"`
+		RevealedAt     int64  `json:"revealed_at,omitempty" bson:"revealed_at"`
+	}
+
+	// FairShot records the inputs and outcome of one provably-fair hit roll
+	// so it can be recomputed after the server seed is revealed.
+	FairShot struct {
+		SessionID string  `json:"session_id" bson:"session_id"`
+		Nonce     uint64  `json:"nonce" bson:"nonce"`
+		RoomID    string  `json:"room_id" bson:"room_id"`
+		PlayerID  string  `json:"player_id" bson:"player_id"`
+		BulletID  string  `json:"bullet_id" bson:"bullet_id"`
+		FishUID   string  `json:"fish_uid" bson:"fish_uid"`
+		HitRate   float64 `json:"hit_rate" bson:"hit_rate"`
+		Damage    int     `json:"damage" bson:"damage"`
+		HP        int     `json:"hp" bson:"hp"`
+		Payout    int64   `json:"payout" bson:"payout"`
+		Value     float64 `json:"value" bson:"value"`
+		Hit       bool    `json:"hit" bson:"hit"`
+		Killed    bool    `json:"killed" bson:"killed"`
+		Reward    int64   `json:"reward" bson:"reward"`
+		CreatedAt int64   `json:"created_at" bson:"created_at"`
+	}
+
+	// FairVerification is a recorded shot alongside the outcome recomputed
+	// from the revealed seeds.
+	FairVerification struct {
+		Session *FairSession `json:"session"`
+		Shot    *FairShot    `json:"shot"`
+		Value   float64      `json:"value"`
+		Hit     bool         `json:"hit"`
+		Killed  bool         `json:"killed"`
+		Reward  int64        `json:"reward"`
+		Match   bool         `json:"match"`
+	}
+)
+
+func (s *FairSession) IsRevealed() bool {
+	return s.RevealedAt > 0
+}
+
+// Public returns a copy that is safe to show the player: the server seed is
+// withheld until the session has been revealed.
+func (s *FairSession) Public() *FairSession {
+	out := *s
+	if !s.IsRevealed() {
+		out.ServerSeed = ""
+	}
+	return &out
+}
@@ -10,3 +10,13 @@ type (
 		IsBoss  bool    `json:"is_boss" bson:"is_boss"`
 	}
 )
+
+// EffectiveHitRate is the probability a bullet reaching this fish damages
+// it. Fish types created before hit rates existed have zero and always take
+// damage.
+func (f *FishType) EffectiveHitRate() float64 {
+	if f.HitRate <= 0 || f.HitRate > 1 {
+		return 1
+	}
+	return f.HitRate
+}
@@ -0,0 +1,135 @@
+package entity
+
+const (
+	EventRoomCreated    = "room_created"
+	EventPlayerJoined   = "player_joined"
+	EventPlayerLeft     = "player_left"
+	EventGunChanged     = "gun_changed"
+	EventFishSpawned    = "fish_spawned"
+	EventFishEscaped    = "fish_escaped"
+	EventFishKilled     = "fish_killed"
+	EventShotFired      = "shot_fired"
+	EventShotHit        = "shot_hit"
+	EventBulletSettled  = "bullet_settled"
+	EventSkillUsed      = "skill_used"
+	EventBalanceChanged = "balance_changed"
+
+	BalanceReasonBuyIn   = "buy_in"
+	BalanceReasonCashOut = "cash_out"
+	BalanceReasonBet     = "bet"
+	BalanceReasonWin     = "win"
+	BalanceReasonRefund  = "refund"
+	BalanceReasonSkill   = "skill"
+)
+
+type (
+	// GameEvent is one entry in a room's append-only history. Seq is the
+	// per-room event sequence assigned by the event store; RoomSeq is the
+	// Room.Seq the change was persisted under, for matching against what
+	// clients saw. Only the fields relevant to Type are set. At is unix
+	// milliseconds.
+	GameEvent struct {
+		RoomID    string        `json:"room_id" bson:"room_id"`
+		Seq       int64         `json:"seq" bson:"seq"`
+		RoomSeq   int64         `json:"room_seq,omitempty" bson:"room_seq,omitempty"`
+		Type      string        `json:"type" bson:"type"`
+		At        int64         `json:"at" bson:"at"`
+		PlayerID  string        `json:"player_id,omitempty" bson:"player_id,omitempty"`
+		SeatID    int           `json:"seat_id,omitempty" bson:"seat_id,omitempty"`
+		GunID     int           `json:"gun_id,omitempty" bson:"gun_id,omitempty"`
+		Config    *RoomConfig   `json:"config,omitempty" bson:"config,omitempty"`
+		Fish      *FishInstance `json:"fish,omitempty" bson:"fish,omitempty"`
+		Bullet    *Bullet       `json:"bullet,omitempty" bson:"bullet,omitempty"`
+		FishUID   string        `json:"fish_uid,omitempty" bson:"fish_uid,omitempty"`
+		Hit       bool          `json:"hit,omitempty" bson:"hit,omitempty"`
+		Damage    int           `json:"damage,omitempty" bson:"damage,omitempty"`
+		SkillType string        `json:"skill_type,omitempty" bson:"skill_type,omitempty"`
+		Amount    int64         `json:"amount,omitempty" bson:"amount,omitempty"`   // signed balance delta
+		Balance   int64         `json:"balance,omitempty" bson:"balance,omitempty"` // balance after the event
+		Reason    string        `json:"reason,omitempty" bson:"reason,omitempty"`
+	}
+
+	// RoomReplay is a room rebuilt from its event log. Balances covers every
+	// player seen in the log, including those who have since left.
+	RoomReplay struct {
+		Room     *Room            `json:"room"`
+		Balances map[string]int64 `json:"balances"`
+		Seq      int64            `json:"seq"`
+		Events   int              `json:"events"`
+	}
+)
+
+func NewRoomReplay(roomID string) *RoomReplay {
+	return &RoomReplay{
+		Room: &Room{
+			RoomID:  roomID,
+			Players: map[string]*Player{},
+			FishMap: map[string]*FishInstance{},
+			Bullets: map[string]*Bullet{},
+		},
+		Balances: map[string]int64{},
+	}
+}
+
+// Apply folds one event into the replayed state. Events must be applied in
+// Seq order.
+func (r *RoomReplay) Apply(e *GameEvent) {
+	room := r.Room
+	switch e.Type {
+	case EventRoomCreated:
+		room.Status = string(RoomStatusOpen)
+		if e.Config != nil {
+			room.Config = *e.Config
+		}
+	case EventPlayerJoined:
+		r.Balances[e.PlayerID] = e.Balance
+		room.Players[e.PlayerID] = &Player{
+			PlayerID: e.PlayerID,
+			RoomID:   room.RoomID,
+			SeatID:   e.SeatID,
+			Balance:  e.Balance,
+			IsOnline: true,
+		}
+	case EventPlayerLeft:
+		delete(room.Players, e.PlayerID)
+	case EventGunChanged:
+		if p, ok := room.Players[e.PlayerID]; ok {
+			p.GunID = e.GunID
+		}
+	case EventFishSpawned, EventFishKilled:
+		if e.Fish != nil {
+			fish := *e.Fish
+			room.FishMap[fish.FishUID] = &fish
+		}
+	case EventFishEscaped:
+		delete(room.FishMap, e.FishUID)
+	case EventShotFired:
+		if e.Bullet != nil {
+			bullet := *e.Bullet
+			room.Bullets[BulletKey(bullet.PlayerID, bullet.BulletID)] = &bullet
+		}
+	case EventShotHit:
+		if e.Bullet != nil {
+			delete(room.Bullets, BulletKey(e.Bullet.PlayerID, e.Bullet.BulletID))
+		}
+		if e.Fish != nil {
+			fish := *e.Fish
+			room.FishMap[fish.FishUID] = &fish
+		}
+	case EventBulletSettled:
+		if e.Bullet != nil {
+			delete(room.Bullets, BulletKey(e.Bullet.PlayerID, e.Bullet.BulletID))
+		}
+	case EventBalanceChanged:
+		r.Balances[e.PlayerID] = e.Balance
+		if p, ok := room.Players[e.PlayerID]; ok {
+			p.Balance = e.Balance
+		}
+	}
+
+	if e.RoomSeq > room.Seq {
+		room.Seq = e.RoomSeq
+	}
+	r.Seq = e.Seq
+	r.Events++
+}
@@ -1,6 +1,10 @@
 package entity
 
-import apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+import (
+	"time"
+
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
 
 type (
 	Player struct {
@@ -12,9 +16,23 @@ type (
 		SessionID    string `json:"session_id" bson:"session_id"`
 		IsOnline     bool   `json:"is_online" bson:"is_online"`
 		LastActionAt int64  `json:"last_action_at" bson:"last_action_at"`
+
+		SkillCooldowns []SkillCooldown `json:"skill_cooldowns,omitempty" bson:"skill_cooldowns,omitempty"`
+
+		// FairNonce is the next nonce of the seat's provably-fair session. It
+		// is advanced with the room save that records the shot, so a retried
+		// hit rolls the same nonce again instead of drawing a new outcome.
+		FairNonce uint64 `json:"-" bson:"fair_nonce,omitempty"`
+
+		// AppliedWalletTxs holds the most recent wallet transfers whose effect
+		// on Balance has been persisted, so reconciliation can tell whether a
+		// pending transfer reached the game state.
+		AppliedWalletTxs []string `json:"-" bson:"applied_wallet_txs,omitempty"`
 	}
 )
 
+const maxAppliedWalletTxs = 16
+
 func (p *Player) CanSpend(amount int64) bool {
 	return p.Balance >= amount && p.Balance > 0
 }
@@ -35,6 +53,42 @@ func (p *Player) AddReward(amount int64) error {
 	return nil
 }
 
+func (p *Player) Cooldown(skillType string) *SkillCooldown {
+	for i := range p.SkillCooldowns {
+		if p.SkillCooldowns[i].SkillType == skillType {
+			return &p.SkillCooldowns[i]
+		}
+	}
+	return nil
+}
+
+func (p *Player) StartCooldown(now time.Time, skill *Skill) {
+	usedAt := now.UnixMilli()
+	cd := p.Cooldown(skill.SkillType)
+	if cd == nil {
+		p.SkillCooldowns = append(p.SkillCooldowns, SkillCooldown{SkillType: skill.SkillType})
+		cd = &p.SkillCooldowns[len(p.SkillCooldowns)-1]
+	}
+	cd.LastUsedAt = usedAt
+	cd.ReadyAt = usedAt + int64(skill.CooldownMs)
+}
+
+func (p *Player) MarkWalletTxApplied(txID string) {
+	p.AppliedWalletTxs = append(p.AppliedWalletTxs, txID)
+	if len(p.AppliedWalletTxs) > maxAppliedWalletTxs {
+		p.AppliedWalletTxs = p.AppliedWalletTxs[len(p.AppliedWalletTxs)-maxAppliedWalletTxs:]
+	}
+}
+
+func (p *Player) HasAppliedWalletTx(txID string) bool {
+	for _, id := range p.AppliedWalletTxs {
+		if id == txID {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *Player) IsValid() (ok bool, err error) {
 	if p.PlayerID == "" {
 		return false, apperr.New(apperr.CodeInvalidPlayerID, "player id is required")
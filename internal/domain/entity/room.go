@@ -8,14 +8,39 @@ type (
 		Status   string                   `json:"status" bson:"status"`
 		Players  map[string]*Player       `json:"players" bson:"players"`
 		FishMap  map[string]*FishInstance `json:"fish_map" bson:"fish_map"`
+		Bullets  map[string]*Bullet       `json:"bullets" bson:"bullets"`
 		Config   RoomConfig               `json:"config" bson:"config"`
 		RTPState RTPState                 `json:"rtp_state" bson:"rtp_state"`
+		Seq      int64                    `json:"seq" bson:"seq"`
+
+		// BulletsExpireAt is the earliest ExpiresAt of the room's bullets, or
+		// 0 with none in flight. Repositories keep it on save so rooms with
+		// bullets to settle can be found without loading every room.
+		BulletsExpireAt int64 `json:"-" bson:"bullets_expire_at"`
 	}
 	RoomConfig struct {
-		MaxPlayers int `json:"max_players" bson:"max_players"`
+		MaxPlayers          int    `json:"max_players" bson:"max_players"`
+		BulletTTLMs         int    `json:"bullet_ttl_ms" bson:"bullet_ttl_ms"`
+		MaxLiveBullets      int    `json:"max_live_bullets" bson:"max_live_bullets"`
+		ExpiredBulletPolicy string `json:"expired_bullet_policy" bson:"expired_bullet_policy"`
+		ProvablyFair        bool   `json:"provably_fair" bson:"provably_fair"`
+		// GameName and ConfigVersions pin the game config versions, by kind,
+		// picked for the room when it was created.
+		GameName       string           `json:"game_name,omitempty" bson:"game_name,omitempty"`
+		ConfigVersions map[string]int64 `json:"config_versions,omitempty" bson:"config_versions,omitempty"`
 	}
 )
 
+const (
+	DefaultBulletTTLMs    = 5000
+	DefaultMaxLiveBullets = 30
+
+	// ExpiredBulletRefund returns the bullet cost to the shooter when a
+	// bullet is never resolved; ExpiredBulletMiss keeps it as a lost bet.
+	ExpiredBulletRefund = "refund"
+	ExpiredBulletMiss   = "miss"
+)
+
 type RoomStatus string
 
 const (
@@ -69,3 +94,81 @@ func (r *Room) GetAliveFishCount() int {
 	}
 	return count
 }
+
+// NextSeq advances the room's state sequence number. It must be called once
+// per persisted mutation so clients can discard broadcasts older than the
+// last snapshot they received.
+func (r *Room) NextSeq() int64 {
+	r.Seq++
+	return r.Seq
+}
+
+func (c RoomConfig) BulletTTL() int64 {
+	if c.BulletTTLMs > 0 {
+		return int64(c.BulletTTLMs)
+	}
+	return DefaultBulletTTLMs
+}
+
+func (c RoomConfig) LiveBulletCap() int {
+	if c.MaxLiveBullets > 0 {
+		return c.MaxLiveBullets
+	}
+	return DefaultMaxLiveBullets
+}
+
+func (c RoomConfig) RefundsExpiredBullets() bool {
+	return c.ExpiredBulletPolicy != ExpiredBulletMiss
+}
+
+func (r *Room) LiveBulletCount(playerID string) int {
+	count := 0
+	for _, b := range r.Bullets {
+		if b.PlayerID == playerID {
+			count++
+		}
+	}
+	return count
+}
+
+// NextBulletExpiry returns the earliest ExpiresAt of the room's bullets, or
+// 0 with none in flight.
+func (r *Room) NextBulletExpiry() int64 {
+	next := int64(0)
+	for _, b := range r.Bullets {
+		if next == 0 || b.ExpiresAt < next {
+			next = b.ExpiresAt
+		}
+	}
+	return next
+}
+
+// ExpireBullets settles every bullet past its expiry according to the room's
+// expired bullet policy and returns them. Refunds are credited to the seated
+// player in r.Players; the caller persists the affected players.
+func (r *Room) ExpireBullets(nowMs int64) []*Bullet {
+	return r.settleBullets(func(b *Bullet) bool { return b.IsExpired(nowMs) })
+}
+
+// DropBullets settles all of a player's live bullets, e.g. when they leave.
+func (r *Room) DropBullets(playerID string) []*Bullet {
+	return r.settleBullets(func(b *Bullet) bool { return b.PlayerID == playerID })
+}
+
+func (r *Room) settleBullets(match func(*Bullet) bool) []*Bullet {
+	var settled []*Bullet
+	for id, b := range r.Bullets {
+		if !match(b) {
+			continue
+		}
+		delete(r.Bullets, id)
+		settled = append(settled, b)
+		if !r.Config.RefundsExpiredBullets() {
+			continue
+		}
+		if p, ok := r.Players[b.PlayerID]; ok {
+			p.Balance += b.Cost
+		}
+	}
+	return settled
+}
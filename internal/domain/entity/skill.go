@@ -16,6 +16,7 @@ type (
 	SkillCooldown struct {
 		SkillType  string `json:"skill_type" bson:"skill_type"`
 		LastUsedAt int64  `json:"last_used_at" bson:"last_used_at"`
+		ReadyAt    int64  `json:"ready_at" bson:"ready_at"`
 	}
 )
 
@@ -36,6 +37,14 @@ func (sc *SkillCooldown) IsReady(now time.Time, skill *Skill) bool {
 	if sc.LastUsedAt == 0 {
 		return true
 	}
-	elapsed := now.Unix()*1000 - sc.LastUsedAt
+	elapsed := now.UnixMilli() - sc.LastUsedAt
 	return elapsed >= int64(skill.CooldownMs)
 }
+
+func (sc *SkillCooldown) RemainingMs(now time.Time) int64 {
+	remaining := sc.ReadyAt - now.UnixMilli()
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
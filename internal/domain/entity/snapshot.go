@@ -0,0 +1,41 @@
+package entity
+
+type (
+	// RoomSnapshot is a consistent view of a room used to resync a client
+	// after a reconnect. Seq is the room sequence the snapshot was taken at;
+	// broadcasts carrying a lower or equal seq are already reflected in it.
+	RoomSnapshot struct {
+		RoomID     string          `json:"room_id"`
+		Seq        int64           `json:"seq"`
+		ServerTime int64           `json:"server_time"` // unix milliseconds
+		Fish       []FishSnapshot  `json:"fish"`
+		Seats      []SeatSnapshot  `json:"seats"`
+		Self       *PlayerSnapshot `json:"self"`
+	}
+
+	FishSnapshot struct {
+		FishInstance
+		ElapsedMs int64 `json:"elapsed_ms"`
+	}
+
+	SeatSnapshot struct {
+		PlayerID string `json:"player_id"`
+		SeatID   int    `json:"seat_id"`
+		IsOnline bool   `json:"is_online"`
+		Gun      *Gun   `json:"gun,omitempty"`
+	}
+
+	PlayerSnapshot struct {
+		PlayerID  string             `json:"player_id"`
+		SeatID    int                `json:"seat_id"`
+		Balance   int64              `json:"balance"`
+		GunID     int                `json:"gun_id"`
+		Cooldowns []CooldownSnapshot `json:"cooldowns"`
+	}
+
+	CooldownSnapshot struct {
+		SkillType   string `json:"skill_type"`
+		ReadyAt     int64  `json:"ready_at"` // unix milliseconds
+		RemainingMs int64  `json:"remaining_ms"`
+	}
+)
@@ -0,0 +1,26 @@
+package entity
+
+type (
+	WalletTransfer struct {
+		TxID      string `json:"tx_id" bson:"tx_id"`
+		PlayerID  string `json:"player_id" bson:"player_id"`
+		RoomID    string `json:"room_id" bson:"room_id"`
+		Kind      string `json:"kind" bson:"kind"`
+		Amount    int64  `json:"amount" bson:"amount"`
+		Status    string `json:"status" bson:"status"`
+		Attempts  int    `json:"attempts" bson:"attempts"`
+		LastError string `json:"last_error,omitempty" bson:"last_error,omitempty"`
+		CreatedAt int64  `json:"created_at" bson:"created_at"`
+		UpdatedAt int64  `json:"updated_at" bson:"updated_at"`
+	}
+)
+
+const (
+	WalletTransferBuyIn   = "buy_in"
+	WalletTransferCashOut = "cash_out"
+
+	WalletTransferPending    = "pending"
+	WalletTransferCommitted  = "committed"
+	WalletTransferFailed     = "failed"
+	WalletTransferRolledBack = "rolled_back"
+)
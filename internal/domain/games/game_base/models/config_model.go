@@ -0,0 +1,157 @@
+package gameBaseModels
+
+import (
+	"fmt"
+	"strings"
+
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+// ConfigDocument is implemented by every versioned game config document.
+type ConfigDocument interface {
+	Meta() *ConfigMeta
+	Kind() string
+}
+
+func (c *BulletConfig) Kind() string  { return ConfigKindBullets }
+func (c *GameConfig) Kind() string    { return ConfigKindConfigs }
+func (c *GameFeatures) Kind() string  { return ConfigKindFeatures }
+func (c *GamePaths) Kind() string     { return ConfigKindPaths }
+func (c *GameRTP) Kind() string       { return ConfigKindRTPs }
+func (c *GameFishTypes) Kind() string { return ConfigKindFishTypes }
+
+// NewConfigDocument returns an empty document of the given kind.
+func NewConfigDocument(kind string) (ConfigDocument, bool) {
+	switch kind {
+	case ConfigKindBullets:
+		return &BulletConfig{}, true
+	case ConfigKindConfigs:
+		return &GameConfig{}, true
+	case ConfigKindFeatures:
+		return &GameFeatures{}, true
+	case ConfigKindPaths:
+		return &GamePaths{}, true
+	case ConfigKindRTPs:
+		return &GameRTP{}, true
+	case ConfigKindFishTypes:
+		return &GameFishTypes{}, true
+	}
+	return nil, false
+}
+
+// ConfigVersion is an immutable copy of a config document as written.
+type ConfigVersion struct {
+	Kind      string         `json:"kind"`
+	GameName  string         `json:"game_name"`
+	Version   int64          `json:"version"`
+	Author    string         `json:"author"`
+	CreatedAt int64          `json:"created_at"`
+	Document  ConfigDocument `json:"document"`
+}
+
+// ConfigRollout says which version of a config document new rooms get.
+// Percent of new rooms, chosen by hashing the room id, are pinned to
+// CandidateVersion; the rest get StableVersion, which is also the version
+// held in the document's main collection.
+type ConfigRollout struct {
+	Kind             string `json:"kind" bson:"kind"`
+	GameName         string `json:"game_name" bson:"game_name"`
+	StableVersion    int64  `json:"stable_version" bson:"stable_version"`
+	CandidateVersion int64  `json:"candidate_version,omitempty" bson:"candidate_version"`
+	Percent          int    `json:"percent" bson:"percent"`
+	UpdatedBy        string `json:"updated_by" bson:"updated_by"`
+	UpdatedAt        int64  `json:"updated_at" bson:"updated_at"`
+}
+
+// ConfigKinds lists every config document kind.
+var ConfigKinds = []string{
+	ConfigKindBullets,
+	ConfigKindConfigs,
+	ConfigKindFeatures,
+	ConfigKindPaths,
+	ConfigKindRTPs,
+	ConfigKindFishTypes,
+}
+
+// Violation is one problem found while validating game config.
+type Violation struct {
+	Kind    string `json:"kind"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s.%s: %s", v.Kind, v.Field, v.Message)
+}
+
+// ValidationError carries every violation found in a rejected write. It
+// matches apperr.ErrInvalidGameConfig under errors.Is.
+type ValidationError struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.String()
+	}
+	return apperr.ErrInvalidGameConfig.Error() + ": " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return apperr.ErrInvalidGameConfig
+}
+
+// ConfigSet holds the config documents of one game. A field is nil when the
+// game has no document of that kind.
+type ConfigSet struct {
+	GameName  string
+	Bullets   *BulletConfig
+	Config    *GameConfig
+	Features  *GameFeatures
+	Paths     *GamePaths
+	RTP       *GameRTP
+	FishTypes *GameFishTypes
+}
+
+// Put stores doc in the field for its kind, replacing any previous one.
+func (s *ConfigSet) Put(doc ConfigDocument) {
+	switch d := doc.(type) {
+	case *BulletConfig:
+		s.Bullets = d
+	case *GameConfig:
+		s.Config = d
+	case *GameFeatures:
+		s.Features = d
+	case *GamePaths:
+		s.Paths = d
+	case *GameRTP:
+		s.RTP = d
+	case *GameFishTypes:
+		s.FishTypes = d
+	}
+}
+
+// Documents returns the documents present in the set, in ConfigKinds order.
+func (s *ConfigSet) Documents() []ConfigDocument {
+	var docs []ConfigDocument
+	if s.Bullets != nil {
+		docs = append(docs, s.Bullets)
+	}
+	if s.Config != nil {
+		docs = append(docs, s.Config)
+	}
+	if s.Features != nil {
+		docs = append(docs, s.Features)
+	}
+	if s.Paths != nil {
+		docs = append(docs, s.Paths)
+	}
+	if s.RTP != nil {
+		docs = append(docs, s.RTP)
+	}
+	if s.FishTypes != nil {
+		docs = append(docs, s.FishTypes)
+	}
+	return docs
+}
@@ -1,5 +1,30 @@
 package gameBaseModels
 
+// Game config document kinds. Each is both the Mongo collection name and the
+// config type segment of its game_config:* cache key.
+const (
+	ConfigKindBullets   = "bullets"
+	ConfigKindConfigs   = "configs"
+	ConfigKindFeatures  = "features"
+	ConfigKindPaths     = "paths"
+	ConfigKindRTPs      = "rtps"
+	ConfigKindFishTypes = "types"
+)
+
+// ConfigMeta is the header shared by every game config document. Version
+// starts at 1 and is bumped on every admin write; Author and CreatedAt (unix
+// seconds) describe the write that produced it.
+type ConfigMeta struct {
+	GameName  string `json:"game_name" bson:"game_name"`
+	Version   int64  `json:"version" bson:"version"`
+	Author    string `json:"author,omitempty" bson:"author,omitempty"`
+	CreatedAt int64  `json:"created_at,omitempty" bson:"created_at,omitempty"`
+}
+
+func (m *ConfigMeta) Meta() *ConfigMeta {
+	return m
+}
+
 type GameConfigDocument struct {
 	ID       map[string]interface{} `json:"_id" bson:"_id"`
 	GameName string                 `json:"game_name" bson:"game_name"`
@@ -8,9 +33,9 @@ type GameConfigDocument struct {
 
 // Bullet Configuration
 type BulletConfig struct {
-	ID       map[string]interface{} `json:"_id" bson:"_id"`
-	GameName string                 `json:"game_name" bson:"game_name"`
-	Data     BulletData             `json:"data" bson:"data"`
+	ID         map[string]interface{} `json:"_id" bson:"_id"`
+	ConfigMeta `bson:",inline"`
+	Data       BulletData `json:"data" bson:"data"`
 }
 
 type BulletData struct {
@@ -26,9 +51,9 @@ type BulletInfo struct {
 
 // Game Config - Betting levels and general parameters
 type GameConfig struct {
-	ID       map[string]interface{} `json:"_id" bson:"_id"`
-	GameName string                 `json:"game_name" bson:"game_name"`
-	Data     GameConfigData         `json:"data" bson:"data"`
+	ID         map[string]interface{} `json:"_id" bson:"_id"`
+	ConfigMeta `bson:",inline"`
+	Data       GameConfigData `json:"data" bson:"data"`
 }
 
 type GameConfigData struct {
@@ -42,9 +67,9 @@ type GameConfigData struct {
 
 // Features - Custom features per game
 type GameFeatures struct {
-	ID       map[string]interface{} `json:"_id" bson:"_id"`
-	GameName string                 `json:"game_name" bson:"game_name"`
-	Data     FeaturesData           `json:"data" bson:"data"`
+	ID         map[string]interface{} `json:"_id" bson:"_id"`
+	ConfigMeta `bson:",inline"`
+	Data       FeaturesData `json:"data" bson:"data"`
 }
 
 type FeaturesData struct {
@@ -75,9 +100,9 @@ type Multiplier struct {
 
 // Paths - Fish paths
 type GamePaths struct {
-	ID       map[string]interface{} `json:"_id" bson:"_id"`
-	GameName string                 `json:"game_name" bson:"game_name"`
-	Data     PathData               `json:"data" bson:"data"`
+	ID         map[string]interface{} `json:"_id" bson:"_id"`
+	ConfigMeta `bson:",inline"`
+	Data       PathData `json:"data" bson:"data"`
 }
 
 type PathData struct {
@@ -99,9 +124,9 @@ type Coordinate struct {
 
 // RTP - Return to Player
 type GameRTP struct {
-	ID       map[string]interface{} `json:"_id" bson:"_id"`
-	GameName string                 `json:"game_name" bson:"game_name"`
-	Data     RTPData                `json:"data" bson:"data"`
+	ID         map[string]interface{} `json:"_id" bson:"_id"`
+	ConfigMeta `bson:",inline"`
+	Data       RTPData `json:"data" bson:"data"`
 }
 
 type RTPData struct {
@@ -112,9 +137,9 @@ type RTPData struct {
 
 // Fish Types
 type GameFishTypes struct {
-	ID       map[string]interface{} `json:"_id" bson:"_id"`
-	GameName string                 `json:"game_name" bson:"game_name"`
-	Data     FishTypeData           `json:"data" bson:"data"`
+	ID         map[string]interface{} `json:"_id" bson:"_id"`
+	ConfigMeta `bson:",inline"`
+	Data       FishTypeData `json:"data" bson:"data"`
 }
 
 type FishTypeData struct {
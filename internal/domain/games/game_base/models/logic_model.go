@@ -1 +1,9 @@
-package gameBaseModels
\ No newline at end of file
+package gameBaseModels
+
+// HitInput describes a bullet reaching a fish.
+type HitInput struct {
+	HitRate float64 // probability in [0, 1] that the bullet damages the fish
+	Damage  int
+	HP      int   // fish hp remaining before this bullet
+	Payout  int64 // paid to the shooter when the fish dies
+}
@@ -1 +1,10 @@
-package gameBaseModels
\ No newline at end of file
+package gameBaseModels
+
+// HitOutcome is the result of resolving a single bullet against a fish.
+type HitOutcome struct {
+	Hit         bool  `json:"hit"`
+	Damage      int   `json:"damage"`
+	RemainingHP int   `json:"remaining_hp"`
+	Killed      bool  `json:"killed"`
+	Payout      int64 `json:"payout"`
+}
@@ -0,0 +1,69 @@
+package gameBaseSevices
+
+import (
+	"fmt"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+)
+
+// ValidateGame checks every document of a game on its own and against each
+// other, and returns all violations found. References must resolve: an RTP
+// entry or multiplier for a fish or bullet id is a violation when the
+// defining document does not contain that id or does not exist.
+func ValidateGame(set *gameBaseModels.ConfigSet) []gameBaseModels.Violation {
+	var list []gameBaseModels.Violation
+	for _, doc := range set.Documents() {
+		list = append(list, ValidateDocument(doc)...)
+	}
+
+	fishIDs := map[int]bool{}
+	if set.FishTypes != nil {
+		for _, f := range set.FishTypes.Data.FishTypes {
+			fishIDs[f.FishID] = true
+		}
+	}
+	bulletIDs := map[int]bool{}
+	if set.Bullets != nil {
+		for _, b := range set.Bullets.Data.Bullets {
+			bulletIDs[b.BulletID] = true
+		}
+	}
+
+	if set.RTP != nil {
+		v := &violations{kind: gameBaseModels.ConfigKindRTPs}
+		for _, fishID := range sortedKeys(set.RTP.Data.FishRTPMap) {
+			if !fishIDs[fishID] {
+				v.add(fmt.Sprintf("data.fish_rtp_map[%d]", fishID), "fish id %d is not defined in %s", fishID, gameBaseModels.ConfigKindFishTypes)
+			}
+		}
+		for _, bulletID := range sortedKeys(set.RTP.Data.BulletRTPMap) {
+			if !bulletIDs[bulletID] {
+				v.add(fmt.Sprintf("data.bullet_rtp_map[%d]", bulletID), "bullet id %d is not defined in %s", bulletID, gameBaseModels.ConfigKindBullets)
+			}
+		}
+		list = append(list, v.list...)
+	}
+
+	if set.Features != nil {
+		v := &violations{kind: gameBaseModels.ConfigKindFeatures}
+		for i, m := range set.Features.Data.Multipliers {
+			if !fishIDs[m.FishType] {
+				v.add(fmt.Sprintf("data.multipliers[%d].fish_type", i), "fish id %d is not defined in %s", m.FishType, gameBaseModels.ConfigKindFishTypes)
+			}
+		}
+		list = append(list, v.list...)
+	}
+
+	if set.Config != nil && set.Bullets != nil {
+		// A bullet costing more than the table maximum can never be fired.
+		v := &violations{kind: gameBaseModels.ConfigKindBullets}
+		for i, b := range set.Bullets.Data.Bullets {
+			if b.Cost > set.Config.Data.MaxBet {
+				v.add(fmt.Sprintf("data.bullets[%d].cost", i), "%d is above max_bet %d in %s", b.Cost, set.Config.Data.MaxBet, gameBaseModels.ConfigKindConfigs)
+			}
+		}
+		list = append(list, v.list...)
+	}
+
+	return list
+}
@@ -0,0 +1,24 @@
+package gameBaseSevices
+
+import (
+	"hash/fnv"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+)
+
+// RolloutBucket maps a room id to a stable bucket in [0, 100).
+func RolloutBucket(roomID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(roomID))
+	return int(h.Sum32() % 100)
+}
+
+// PinnedVersion picks the config version a new room starts with under a
+// rollout. The same room id always lands in the same bucket, so widening a
+// rollout only ever adds rooms to the candidate.
+func PinnedVersion(rollout *gameBaseModels.ConfigRollout, roomID string) int64 {
+	if rollout.CandidateVersion > 0 && RolloutBucket(roomID) < rollout.Percent {
+		return rollout.CandidateVersion
+	}
+	return rollout.StableVersion
+}
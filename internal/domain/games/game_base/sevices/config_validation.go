@@ -0,0 +1,227 @@
+package gameBaseSevices
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+)
+
+var fishRarities = map[string]bool{
+	"":          true,
+	"common":    true,
+	"uncommon":  true,
+	"rare":      true,
+	"epic":      true,
+	"legendary": true,
+}
+
+// ValidateDocument checks a single config document on its own and returns
+// every violation found.
+func ValidateDocument(doc gameBaseModels.ConfigDocument) []gameBaseModels.Violation {
+	v := &violations{kind: doc.Kind()}
+	if doc.Meta().GameName == "" {
+		v.add("game_name", "is required")
+	}
+
+	switch d := doc.(type) {
+	case *gameBaseModels.BulletConfig:
+		validateBullets(v, d)
+	case *gameBaseModels.GameConfig:
+		validateGameConfig(v, d)
+	case *gameBaseModels.GameFeatures:
+		validateFeatures(v, d)
+	case *gameBaseModels.GamePaths:
+		validatePaths(v, d)
+	case *gameBaseModels.GameRTP:
+		validateRTP(v, d)
+	case *gameBaseModels.GameFishTypes:
+		validateFishTypes(v, d)
+	}
+	return v.list
+}
+
+type violations struct {
+	kind string
+	list []gameBaseModels.Violation
+}
+
+func (v *violations) add(field, format string, args ...interface{}) {
+	v.list = append(v.list, gameBaseModels.Violation{
+		Kind:    v.kind,
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func validateBullets(v *violations, d *gameBaseModels.BulletConfig) {
+	if len(d.Data.Bullets) == 0 {
+		v.add("data.bullets", "must not be empty")
+	}
+	seen := map[int]bool{}
+	for i, b := range d.Data.Bullets {
+		field := fmt.Sprintf("data.bullets[%d]", i)
+		if b.BulletID <= 0 {
+			v.add(field+".bullet_id", "must be > 0")
+		} else if seen[b.BulletID] {
+			v.add(field+".bullet_id", "duplicate bullet id %d", b.BulletID)
+		}
+		seen[b.BulletID] = true
+		if b.Name == "" {
+			v.add(field+".name", "is required")
+		}
+		if b.Cost <= 0 {
+			v.add(field+".cost", "must be > 0")
+		}
+		if b.Damage <= 0 {
+			v.add(field+".damage", "must be > 0")
+		}
+	}
+}
+
+func validateGameConfig(v *violations, d *gameBaseModels.GameConfig) {
+	c := d.Data
+	if c.MinBet <= 0 {
+		v.add("data.min_bet", "must be > 0")
+	}
+	if c.MaxBet < c.MinBet {
+		v.add("data.max_bet", "must be >= min_bet")
+	}
+	for i, level := range c.BetLevels {
+		field := fmt.Sprintf("data.bet_levels[%d]", i)
+		if level < c.MinBet || level > c.MaxBet {
+			v.add(field, "%d is outside [min_bet, max_bet]", level)
+		}
+		if i > 0 && level <= c.BetLevels[i-1] {
+			v.add(field, "bet levels must be strictly increasing")
+		}
+	}
+	if c.GameDuration < 0 {
+		v.add("data.game_duration", "must be >= 0")
+	}
+	if c.MaxPlayers <= 0 {
+		v.add("data.max_players", "must be > 0")
+	}
+	if c.RoomCapacity <= 0 {
+		v.add("data.room_capacity", "must be > 0")
+	}
+}
+
+func validateFeatures(v *violations, d *gameBaseModels.GameFeatures) {
+	seen := map[int]bool{}
+	for i, s := range d.Data.SpecialSkills {
+		field := fmt.Sprintf("data.special_skills[%d]", i)
+		if s.SkillID <= 0 {
+			v.add(field+".skill_id", "must be > 0")
+		} else if seen[s.SkillID] {
+			v.add(field+".skill_id", "duplicate skill id %d", s.SkillID)
+		}
+		seen[s.SkillID] = true
+		if s.SkillName == "" {
+			v.add(field+".skill_name", "is required")
+		}
+		if s.Cost < 0 {
+			v.add(field+".cost", "must be >= 0")
+		}
+		if s.Cooldown < 0 {
+			v.add(field+".cooldown", "must be >= 0")
+		}
+	}
+	for i, r := range d.Data.SpecialRewards {
+		field := fmt.Sprintf("data.special_rewards[%d]", i)
+		if r.Amount < 0 {
+			v.add(field+".amount", "must be >= 0")
+		}
+		if r.Chance < 0 || r.Chance > 100 {
+			v.add(field+".chance", "must be between 0 and 100")
+		}
+	}
+	for i, m := range d.Data.Multipliers {
+		if m.Multiplier <= 0 {
+			v.add(fmt.Sprintf("data.multipliers[%d].multiplier", i), "must be > 0")
+		}
+	}
+}
+
+func validatePaths(v *violations, d *gameBaseModels.GamePaths) {
+	seen := map[int]bool{}
+	for i, p := range d.Data.Paths {
+		field := fmt.Sprintf("data.paths[%d]", i)
+		if p.PathID <= 0 {
+			v.add(field+".path_id", "must be > 0")
+		} else if seen[p.PathID] {
+			v.add(field+".path_id", "duplicate path id %d", p.PathID)
+		}
+		seen[p.PathID] = true
+		if len(p.Coordinates) < 2 {
+			v.add(field+".coordinates", "needs at least 2 points")
+		}
+		if p.Duration <= 0 {
+			v.add(field+".duration", "must be > 0")
+		}
+	}
+}
+
+func validateRTP(v *violations, d *gameBaseModels.GameRTP) {
+	if d.Data.RTPRate <= 0 || d.Data.RTPRate > 100 {
+		v.add("data.rtp_rate", "must be between 1 and 100")
+	}
+	for _, fishID := range sortedKeys(d.Data.FishRTPMap) {
+		if rate := d.Data.FishRTPMap[fishID]; rate <= 0 || rate > 100 {
+			v.add(fmt.Sprintf("data.fish_rtp_map[%d]", fishID), "must be between 1 and 100")
+		}
+	}
+	for _, bulletID := range sortedKeys(d.Data.BulletRTPMap) {
+		if rate := d.Data.BulletRTPMap[bulletID]; rate <= 0 || rate > 100 {
+			v.add(fmt.Sprintf("data.bullet_rtp_map[%d]", bulletID), "must be between 1 and 100")
+		}
+	}
+}
+
+func validateFishTypes(v *violations, d *gameBaseModels.GameFishTypes) {
+	if len(d.Data.FishTypes) == 0 {
+		v.add("data.fish_types", "must not be empty")
+	}
+	seen := map[int]bool{}
+	spawnTotal := 0
+	for i, f := range d.Data.FishTypes {
+		field := fmt.Sprintf("data.fish_types[%d]", i)
+		if f.FishID <= 0 {
+			v.add(field+".fish_id", "must be > 0")
+		} else if seen[f.FishID] {
+			v.add(field+".fish_id", "duplicate fish id %d", f.FishID)
+		}
+		seen[f.FishID] = true
+		if f.HP <= 0 {
+			v.add(field+".hp", "must be > 0")
+		}
+		if f.BaseReward < 0 {
+			v.add(field+".base_reward", "must be >= 0")
+		}
+		if !fishRarities[f.Rarity] {
+			v.add(field+".rarity", "unknown rarity %q", f.Rarity)
+		}
+		if f.SpawnRate < 0 || f.SpawnRate > 100 {
+			v.add(field+".spawn_rate", "must be between 0 and 100")
+		} else {
+			spawnTotal += f.SpawnRate
+		}
+		if f.Multiplier < 0 {
+			v.add(field+".multiplier", "must be >= 0")
+		}
+	}
+	// Spawn rates are percentages of the spawn table, so together they must
+	// leave some fish able to spawn and cannot exceed the whole table.
+	if len(d.Data.FishTypes) > 0 && (spawnTotal <= 0 || spawnTotal > 100) {
+		v.add("data.fish_types", "spawn_rate total %d must be between 1 and 100", spawnTotal)
+	}
+}
+
+func sortedKeys(m map[int]int) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
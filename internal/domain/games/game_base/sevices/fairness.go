@@ -0,0 +1,27 @@
+package gameBaseSevices
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+)
+
+// HashServerSeed is the commitment published before any shot: the hex
+// SHA-256 of the hex-encoded server seed.
+func HashServerSeed(serverSeed string) string {
+	sum := sha256.Sum256([]byte(serverSeed))
+	return hex.EncodeToString(sum[:])
+}
+
+// FairRoll derives the provably-fair draw for a nonce:
+// HMAC-SHA256(key=serverSeed, msg=clientSeed+":"+nonce), with the top 53
+// bits of the digest scaled into [0, 1). Seeds are used as the strings the
+// player sees so the roll can be recomputed with any HMAC tool.
+func FairRoll(serverSeed, clientSeed string, nonce uint64) float64 {
+	mac := hmac.New(sha256.New, []byte(serverSeed))
+	mac.Write([]byte(clientSeed + ":" + strconv.FormatUint(nonce, 10)))
+	sum := mac.Sum(nil)
+	return float64(binary.BigEndian.Uint64(sum[:8])>>11) / (1 << 53)
+}
@@ -1 +1,57 @@
-package gameBaseSevices
\ No newline at end of file
+package gameBaseSevices
+
+import (
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+)
+
+// ShotsToKill is the number of hits a fish with hp needs from a bullet.
+func ShotsToKill(hp, damage int) int {
+	if damage <= 0 {
+		return 0
+	}
+	return (hp + damage - 1) / damage
+}
+
+// HitRateForRTP returns the per-bullet hit probability that makes shooting
+// a fish pay back rtpPercent of the stake on average: each kill costs
+// ShotsToKill/p bullets, so p = rtp * cost * shotsToKill / payout. The
+// result is capped at 1, in which case the realized RTP is higher.
+func HitRateForRTP(rtpPercent int, cost, damage, hp int, payout int64) float64 {
+	shots := ShotsToKill(hp, damage)
+	if payout <= 0 || shots == 0 || cost <= 0 {
+		return 0
+	}
+	p := float64(rtpPercent) / 100 * float64(cost) * float64(shots) / float64(payout)
+	if p > 1 {
+		return 1
+	}
+	return p
+}
+
+// TargetRTP resolves the RTP percentage for a fish/bullet pair: a fish entry
+// wins over a bullet entry, which wins over the game-wide rate.
+func TargetRTP(rtp *gameBaseModels.RTPData, fishID, bulletID int) int {
+	if v, ok := rtp.FishRTPMap[fishID]; ok {
+		return v
+	}
+	if v, ok := rtp.BulletRTPMap[bulletID]; ok {
+		return v
+	}
+	return rtp.RTPRate
+}
+
+// HitInputFor describes bullet reaching fish, which has hp left, under a
+// game's RTP config: a kill pays the fish's reward scaled by its multiplier,
+// and the hit rate is the one that pays the pair's target RTP back over a
+// full-health fish. The shoot usecase and the simulator both resolve shots
+// through it, so a simulated game plays the same odds as a live one.
+func HitInputFor(rtp *gameBaseModels.RTPData, fish *gameBaseModels.FishType, bullet *gameBaseModels.BulletInfo, hp int) gameBaseModels.HitInput {
+	payout := Payout(fish.BaseReward, fish.Multiplier)
+	target := TargetRTP(rtp, fish.FishID, bullet.BulletID)
+	return gameBaseModels.HitInput{
+		HitRate: HitRateForRTP(target, bullet.Cost, bullet.Damage, fish.HP, payout),
+		Damage:  bullet.Damage,
+		HP:      hp,
+		Payout:  payout,
+	}
+}
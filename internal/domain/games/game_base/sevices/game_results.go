@@ -1 +1,10 @@
-package gameBaseSevices
\ No newline at end of file
+package gameBaseSevices
+
+// Payout is what a kill pays: the fish reward scaled by its multiplier. A
+// multiplier of zero is treated as one.
+func Payout(reward int, multiplier int) int64 {
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	return int64(reward) * int64(multiplier)
+}
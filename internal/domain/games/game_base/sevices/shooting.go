@@ -1 +1,40 @@
-package gameBaseSevices
\ No newline at end of file
+package gameBaseSevices
+
+import "github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+
+// Roller supplies uniform random draws in [0, 1).
+type Roller interface {
+	Float64() float64
+}
+
+// RollerFunc adapts a plain function such as math/rand.Float64 to Roller.
+type RollerFunc func() float64
+
+func (f RollerFunc) Float64() float64 {
+	return f()
+}
+
+// ResolveHit decides whether a bullet damages the fish and whether the fish
+// dies. It draws exactly one value from r per call so outcomes can be
+// replayed from the draw sequence.
+func ResolveHit(r Roller, in gameBaseModels.HitInput) gameBaseModels.HitOutcome {
+	out := gameBaseModels.HitOutcome{RemainingHP: in.HP}
+	if in.HP <= 0 {
+		return out
+	}
+
+	roll := r.Float64()
+	if roll >= in.HitRate {
+		return out
+	}
+
+	out.Hit = true
+	out.Damage = in.Damage
+	out.RemainingHP = in.HP - in.Damage
+	if out.RemainingHP <= 0 {
+		out.RemainingHP = 0
+		out.Killed = true
+		out.Payout = in.Payout
+	}
+	return out
+}
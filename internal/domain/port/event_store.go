@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+// EventStore is the append-only log of room events.
+type EventStore interface {
+	// Append assigns the events consecutive per-room sequence numbers, in
+	// order, and stores them.
+	Append(ctx context.Context, roomID string, events ...*entity.GameEvent) error
+
+	// List returns a room's events with fromSeq <= Seq <= toSeq in sequence
+	// order. A toSeq of zero or less means up to the latest event.
+	List(ctx context.Context, roomID string, fromSeq, toSeq int64) ([]*entity.GameEvent, error)
+}
@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+type FairSessionRepository interface {
+	Save(ctx context.Context, session *entity.FairSession) error
+	GetByID(ctx context.Context, sessionID string) (*entity.FairSession, error)
+
+	// GetActive returns the player's unrevealed session in a room.
+	GetActive(ctx context.Context, roomID, playerID string) (*entity.FairSession, error)
+}
+
+type FairShotRepository interface {
+	Save(ctx context.Context, shot *entity.FairShot) error
+	Get(ctx context.Context, sessionID string, nonce uint64) (*entity.FairShot, error)
+}
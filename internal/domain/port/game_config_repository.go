@@ -26,3 +26,41 @@ type GameConfigRepository interface {
 	// GetGameFishTypes retrieves all fish types for a game
 	GetGameFishTypes(ctx context.Context, gameName string) (*gameBaseModels.GameFishTypes, error)
 }
+
+// GameConfigStore is the writable source of truth for game config documents.
+type GameConfigStore interface {
+	GameConfigRepository
+
+	// GetDocument loads the stored document of a kind for a game.
+	GetDocument(ctx context.Context, kind, gameName string) (gameBaseModels.ConfigDocument, error)
+
+	// SaveDocument replaces the stored document only if it is still at
+	// prevVersion (0 for a document that does not exist yet or predates
+	// versioning) and returns apperr.ErrConfigVersionConflict otherwise.
+	SaveDocument(ctx context.Context, doc gameBaseModels.ConfigDocument, prevVersion int64) error
+
+	// ListGameNames returns every game that has at least one config
+	// document, sorted by name.
+	ListGameNames(ctx context.Context) ([]string, error)
+}
+
+// GameConfigCache drops cached copies of a config document so the next read
+// goes to the store.
+type GameConfigCache interface {
+	Invalidate(ctx context.Context, kind, gameName string) error
+}
+
+// GameConfigVersionStore keeps the immutable history of config documents
+// and the rollout state of each.
+type GameConfigVersionStore interface {
+	// SaveVersion stores a new version. Versions are never overwritten;
+	// saving an existing version returns apperr.ErrConfigVersionConflict.
+	SaveVersion(ctx context.Context, version *gameBaseModels.ConfigVersion) error
+	GetVersion(ctx context.Context, kind, gameName string, version int64) (*gameBaseModels.ConfigVersion, error)
+
+	// ListVersions returns the newest versions first.
+	ListVersions(ctx context.Context, kind, gameName string, limit int) ([]*gameBaseModels.ConfigVersion, error)
+
+	GetRollout(ctx context.Context, kind, gameName string) (*gameBaseModels.ConfigRollout, error)
+	SaveRollout(ctx context.Context, rollout *gameBaseModels.ConfigRollout) error
+}
@@ -0,0 +1,12 @@
+package port
+
+// GameplayMetrics counts what is played, labelled by the game a room plays
+// ("" for rooms without a game config). Each call is made once the room
+// mutation it describes has been saved.
+type GameplayMetrics interface {
+	// ShotFired counts a bullet fired from gunID and the bet it cost.
+	ShotFired(game string, gunID int, bet int64)
+	// ShotResolved counts a bullet reaching its target, whether it hit and
+	// killed, and what it won.
+	ShotResolved(game string, hit, killed bool, win int64)
+}
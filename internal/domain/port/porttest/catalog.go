@@ -0,0 +1,48 @@
+package porttest
+
+import (
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+// FishRepository checks a port.FishRepository. The port is read-only, so
+// newRepo is given the fish types to seed it with.
+func FishRepository(t *testing.T, newRepo func(t *testing.T, seed []*entity.FishType) port.FishRepository) {
+	t.Run("GetTypeByID", func(t *testing.T) {
+		boss := &entity.FishType{FishID: uniqueInt(t), BaseHP: 500, Reward: 150, HitRate: 0.15, Speed: 0.4, IsBoss: true}
+		repo := newRepo(t, []*entity.FishType{boss})
+
+		got, err := repo.GetTypeByID(ctx, boss.FishID)
+		requireNoError(t, err, "GetTypeByID")
+		if *got != *boss {
+			t.Fatalf("GetTypeByID = %+v, want %+v", got, boss)
+		}
+	})
+
+	t.Run("GetTypeByIDMissing", func(t *testing.T) {
+		_, err := newRepo(t, nil).GetTypeByID(ctx, uniqueInt(t))
+		requireNotFound(t, err)
+	})
+}
+
+// GunRepository checks a port.GunRepository. The port is read-only, so
+// newRepo is given the guns to seed it with.
+func GunRepository(t *testing.T, newRepo func(t *testing.T, seed []*entity.Gun) port.GunRepository) {
+	t.Run("GetByID", func(t *testing.T) {
+		gun := &entity.Gun{GunID: uniqueInt(t), BulletCost: 15, Damage: 40, FireRateMs: 250}
+		repo := newRepo(t, []*entity.Gun{gun})
+
+		got, err := repo.GetByID(ctx, gun.GunID)
+		requireNoError(t, err, "GetByID")
+		if *got != *gun {
+			t.Fatalf("GetByID = %+v, want %+v", got, gun)
+		}
+	})
+
+	t.Run("GetByIDMissing", func(t *testing.T) {
+		_, err := newRepo(t, nil).GetByID(ctx, uniqueInt(t))
+		requireNotFound(t, err)
+	})
+}
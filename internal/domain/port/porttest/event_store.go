@@ -0,0 +1,70 @@
+package porttest
+
+import (
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+// EventStore checks a port.EventStore.
+func EventStore(t *testing.T, newStore func(t *testing.T) port.EventStore) {
+	t.Run("AppendNumbersPerRoom", func(t *testing.T) {
+		store := newStore(t)
+		roomID, otherRoomID := uniqueID(t, "room"), uniqueID(t, "room")
+
+		first := []*entity.GameEvent{{Type: entity.EventRoomCreated}, {Type: entity.EventPlayerJoined, PlayerID: "p1"}}
+		requireNoError(t, store.Append(ctx, roomID, first...), "Append")
+		requireNoError(t, store.Append(ctx, otherRoomID, &entity.GameEvent{Type: entity.EventRoomCreated}), "Append to another room")
+		requireNoError(t, store.Append(ctx, roomID, &entity.GameEvent{Type: entity.EventPlayerLeft, PlayerID: "p1"}), "second Append")
+		requireNoError(t, store.Append(ctx, roomID), "empty Append")
+
+		for i, e := range first {
+			if e.RoomID != roomID || e.Seq != int64(i+1) {
+				t.Fatalf("appended event %d has room %q seq %d, want %q seq %d", i, e.RoomID, e.Seq, roomID, i+1)
+			}
+		}
+
+		events, err := store.List(ctx, roomID, 1, 0)
+		requireNoError(t, err, "List")
+		wantTypes := []string{entity.EventRoomCreated, entity.EventPlayerJoined, entity.EventPlayerLeft}
+		if len(events) != len(wantTypes) {
+			t.Fatalf("List returned %d events, want %d", len(events), len(wantTypes))
+		}
+		for i, e := range events {
+			if e.Seq != int64(i+1) || e.Type != wantTypes[i] || e.RoomID != roomID {
+				t.Fatalf("List[%d] = seq %d %s in %q, want seq %d %s", i, e.Seq, e.Type, e.RoomID, i+1, wantTypes[i])
+			}
+		}
+
+		other, err := store.List(ctx, otherRoomID, 1, 0)
+		requireNoError(t, err, "List another room")
+		if len(other) != 1 || other[0].Seq != 1 {
+			t.Fatalf("List of another room = %d events, want one with seq 1", len(other))
+		}
+	})
+
+	t.Run("ListRange", func(t *testing.T) {
+		store := newStore(t)
+		roomID := uniqueID(t, "room")
+		for i := 0; i < 5; i++ {
+			requireNoError(t, store.Append(ctx, roomID, &entity.GameEvent{Type: entity.EventFishSpawned, At: int64(i)}), "Append")
+		}
+
+		events, err := store.List(ctx, roomID, 2, 4)
+		requireNoError(t, err, "List")
+		if len(events) != 3 || events[0].Seq != 2 || events[2].Seq != 4 {
+			t.Fatalf("List(2, 4) returned %d events", len(events))
+		}
+		events, err = store.List(ctx, roomID, 4, 0)
+		requireNoError(t, err, "List")
+		if len(events) != 2 || events[1].Seq != 5 || events[1].At != 4 {
+			t.Fatalf("List(4, latest) returned %d events", len(events))
+		}
+		events, err = store.List(ctx, uniqueID(t, "room"), 1, 0)
+		requireNoError(t, err, "List of an unknown room")
+		if len(events) != 0 {
+			t.Fatalf("List of an unknown room returned %d events, want 0", len(events))
+		}
+	})
+}
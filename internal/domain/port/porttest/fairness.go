@@ -0,0 +1,99 @@
+package porttest
+
+import (
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+// FairSessionRepository checks a port.FairSessionRepository.
+func FairSessionRepository(t *testing.T, newRepo func(t *testing.T) port.FairSessionRepository) {
+	session := func(t *testing.T, roomID, playerID string, createdAt int64) *entity.FairSession {
+		return &entity.FairSession{
+			SessionID:      uniqueID(t, "session"),
+			RoomID:         roomID,
+			PlayerID:       playerID,
+			ServerSeed:     "seed",
+			ServerSeedHash: "hash",
+			ClientSeed:     "client",
+			CreatedAt:      createdAt,
+		}
+	}
+
+	t.Run("GetMissing", func(t *testing.T) {
+		repo := newRepo(t)
+		_, err := repo.GetByID(ctx, uniqueID(t, "session"))
+		requireNotFound(t, err)
+		_, err = repo.GetActive(ctx, uniqueID(t, "room"), uniqueID(t, "player"))
+		requireNotFound(t, err)
+	})
+
+	t.Run("SaveRoundTrip", func(t *testing.T) {
+		repo := newRepo(t)
+		s := session(t, uniqueID(t, "room"), uniqueID(t, "player"), 1)
+		requireNoError(t, repo.Save(ctx, s), "Save")
+
+		got, err := repo.GetByID(ctx, s.SessionID)
+		requireNoError(t, err, "GetByID")
+		if *got != *s {
+			t.Fatalf("GetByID = %+v, want %+v", got, s)
+		}
+	})
+
+	t.Run("GetActiveSkipsRevealed", func(t *testing.T) {
+		repo := newRepo(t)
+		roomID, playerID := uniqueID(t, "room"), uniqueID(t, "player")
+		older := session(t, roomID, playerID, 1)
+		newer := session(t, roomID, playerID, 2)
+		requireNoError(t, repo.Save(ctx, older), "Save")
+		requireNoError(t, repo.Save(ctx, newer), "Save")
+
+		active, err := repo.GetActive(ctx, roomID, playerID)
+		requireNoError(t, err, "GetActive")
+		if active.SessionID != newer.SessionID {
+			t.Fatalf("GetActive = %s, want the newest session %s", active.SessionID, newer.SessionID)
+		}
+
+		newer.RevealedAt = 3
+		requireNoError(t, repo.Save(ctx, newer), "Save revealed")
+		active, err = repo.GetActive(ctx, roomID, playerID)
+		requireNoError(t, err, "GetActive")
+		if active.SessionID != older.SessionID {
+			t.Fatalf("GetActive = %s, want the unrevealed session %s", active.SessionID, older.SessionID)
+		}
+
+		older.RevealedAt = 3
+		requireNoError(t, repo.Save(ctx, older), "Save revealed")
+		_, err = repo.GetActive(ctx, roomID, playerID)
+		requireNotFound(t, err)
+	})
+
+}
+
+// FairShotRepository checks a port.FairShotRepository.
+func FairShotRepository(t *testing.T, newRepo func(t *testing.T) port.FairShotRepository) {
+	t.Run("SaveRoundTrip", func(t *testing.T) {
+		repo := newRepo(t)
+		sessionID := uniqueID(t, "session")
+		shots := []*entity.FairShot{
+			{SessionID: sessionID, Nonce: 0, BulletID: "b0", HitRate: 0.5, Damage: 10, HP: 20, Value: 0.25, Hit: true, Reward: 0},
+			{SessionID: sessionID, Nonce: 1, BulletID: "b1", HitRate: 0.5, Damage: 10, HP: 20, Value: 0.75, Killed: true, Reward: 50},
+		}
+		for _, s := range shots {
+			requireNoError(t, repo.Save(ctx, s), "Save")
+		}
+
+		for _, want := range shots {
+			got, err := repo.Get(ctx, sessionID, want.Nonce)
+			requireNoError(t, err, "Get")
+			if *got != *want {
+				t.Fatalf("Get(%d) = %+v, want %+v", want.Nonce, got, want)
+			}
+		}
+		_, err := repo.Get(ctx, sessionID, 2)
+		requireNotFound(t, err)
+		_, err = repo.Get(ctx, uniqueID(t, "session"), 0)
+		requireNotFound(t, err)
+	})
+}
@@ -0,0 +1,172 @@
+package porttest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+// sampleDocuments returns one document of every kind for a game.
+func sampleDocuments(gameName string, version int64) []gameBaseModels.ConfigDocument {
+	meta := gameBaseModels.ConfigMeta{GameName: gameName, Version: version, Author: "porttest"}
+	return []gameBaseModels.ConfigDocument{
+		&gameBaseModels.BulletConfig{ConfigMeta: meta, Data: gameBaseModels.BulletData{
+			Bullets: []gameBaseModels.BulletInfo{{BulletID: 1, Name: "Pea Shot", Cost: 5, Damage: 10}},
+		}},
+		&gameBaseModels.GameConfig{ConfigMeta: meta, Data: gameBaseModels.GameConfigData{
+			MinBet: 10, MaxBet: 1000, BetLevels: []int{10, 100, 1000}, MaxPlayers: 4,
+		}},
+		&gameBaseModels.GameFeatures{ConfigMeta: meta, Data: gameBaseModels.FeaturesData{
+			Multipliers: []gameBaseModels.Multiplier{{FishType: 1, Multiplier: 3}},
+		}},
+		&gameBaseModels.GamePaths{ConfigMeta: meta, Data: gameBaseModels.PathData{
+			Paths: []gameBaseModels.PathInfo{{PathID: 1, PathName: "line", Coordinates: []gameBaseModels.Coordinate{{X: 0, Y: 1}}, Duration: 5000}},
+		}},
+		&gameBaseModels.GameRTP{ConfigMeta: meta, Data: gameBaseModels.RTPData{
+			RTPRate: 96, FishRTPMap: map[int]int{1: 95}, BulletRTPMap: map[int]int{1: 97},
+		}},
+		&gameBaseModels.GameFishTypes{ConfigMeta: meta, Data: gameBaseModels.FishTypeData{
+			FishTypes: []gameBaseModels.FishType{{FishID: 1, FishName: "Goldfish", HP: 10, BaseReward: 50, SpawnRate: 100, Multiplier: 1}},
+		}},
+	}
+}
+
+// GameConfigRepository checks the read side of game config, including
+// caches in front of a store. newRepo is given the documents to seed it
+// with. Misses may be reported with a kind-specific not-found code, which
+// the config cache passes on to clients.
+func GameConfigRepository(t *testing.T, newRepo func(t *testing.T, seed []gameBaseModels.ConfigDocument) port.GameConfigRepository) {
+	t.Run("GetEveryKind", func(t *testing.T) {
+		gameName := uniqueID(t, "game")
+		repo := newRepo(t, sampleDocuments(gameName, 1))
+
+		bullets, err := repo.GetBulletConfig(ctx, gameName)
+		requireNoError(t, err, "GetBulletConfig")
+		if bullets.GameName != gameName || len(bullets.Data.Bullets) != 1 || bullets.Data.Bullets[0].Cost != 5 {
+			t.Fatalf("GetBulletConfig = %+v", bullets)
+		}
+		config, err := repo.GetGameConfig(ctx, gameName)
+		requireNoError(t, err, "GetGameConfig")
+		if config.Data.MaxBet != 1000 || len(config.Data.BetLevels) != 3 {
+			t.Fatalf("GetGameConfig = %+v", config)
+		}
+		features, err := repo.GetGameFeatures(ctx, gameName)
+		requireNoError(t, err, "GetGameFeatures")
+		if len(features.Data.Multipliers) != 1 {
+			t.Fatalf("GetGameFeatures = %+v", features)
+		}
+		paths, err := repo.GetGamePaths(ctx, gameName)
+		requireNoError(t, err, "GetGamePaths")
+		if len(paths.Data.Paths) != 1 || len(paths.Data.Paths[0].Coordinates) != 1 {
+			t.Fatalf("GetGamePaths = %+v", paths)
+		}
+		rtp, err := repo.GetGameRTP(ctx, gameName)
+		requireNoError(t, err, "GetGameRTP")
+		if rtp.Data.RTPRate != 96 || rtp.Data.FishRTPMap[1] != 95 || rtp.Data.BulletRTPMap[1] != 97 {
+			t.Fatalf("GetGameRTP = %+v", rtp)
+		}
+		fishTypes, err := repo.GetGameFishTypes(ctx, gameName)
+		requireNoError(t, err, "GetGameFishTypes")
+		if len(fishTypes.Data.FishTypes) != 1 || fishTypes.Data.FishTypes[0].HP != 10 {
+			t.Fatalf("GetGameFishTypes = %+v", fishTypes)
+		}
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		repo := newRepo(t, nil)
+		gameName := uniqueID(t, "game")
+
+		_, err := repo.GetBulletConfig(ctx, gameName)
+		requireConfigNotFound(t, err)
+		_, err = repo.GetGameConfig(ctx, gameName)
+		requireConfigNotFound(t, err)
+		_, err = repo.GetGameFeatures(ctx, gameName)
+		requireConfigNotFound(t, err)
+		_, err = repo.GetGamePaths(ctx, gameName)
+		requireConfigNotFound(t, err)
+		_, err = repo.GetGameRTP(ctx, gameName)
+		requireConfigNotFound(t, err)
+		_, err = repo.GetGameFishTypes(ctx, gameName)
+		requireConfigNotFound(t, err)
+	})
+}
+
+// GameConfigStore checks a port.GameConfigStore, including its read side.
+func GameConfigStore(t *testing.T, newStore func(t *testing.T) port.GameConfigStore) {
+	GameConfigRepository(t, func(t *testing.T, seed []gameBaseModels.ConfigDocument) port.GameConfigRepository {
+		store := newStore(t)
+		for _, doc := range seed {
+			requireNoError(t, store.SaveDocument(ctx, doc, 0), "SaveDocument "+doc.Kind())
+		}
+		return store
+	})
+
+	t.Run("GetDocument", func(t *testing.T) {
+		store := newStore(t)
+		gameName := uniqueID(t, "game")
+		for _, doc := range sampleDocuments(gameName, 1) {
+			requireNoError(t, store.SaveDocument(ctx, doc, 0), "SaveDocument "+doc.Kind())
+		}
+		for _, kind := range gameBaseModels.ConfigKinds {
+			doc, err := store.GetDocument(ctx, kind, gameName)
+			requireNoError(t, err, "GetDocument "+kind)
+			if doc.Kind() != kind || doc.Meta().GameName != gameName || doc.Meta().Version != 1 {
+				t.Fatalf("GetDocument %s = %s %+v", kind, doc.Kind(), doc.Meta())
+			}
+		}
+
+		_, err := store.GetDocument(ctx, gameBaseModels.ConfigKindRTPs, uniqueID(t, "game"))
+		requireNotFound(t, err)
+		if _, err := store.GetDocument(ctx, "nope", gameName); !errorIs(err, apperr.ErrUnknownConfigKind) {
+			t.Fatalf("GetDocument unknown kind: err = %v, want ErrUnknownConfigKind", err)
+		}
+	})
+
+	t.Run("SaveDocumentChecksVersion", func(t *testing.T) {
+		store := newStore(t)
+		gameName := uniqueID(t, "game")
+		doc := &gameBaseModels.GameRTP{ConfigMeta: gameBaseModels.ConfigMeta{GameName: gameName, Version: 1}, Data: gameBaseModels.RTPData{RTPRate: 95}}
+		requireNoError(t, store.SaveDocument(ctx, doc, 0), "first SaveDocument")
+
+		next := &gameBaseModels.GameRTP{ConfigMeta: gameBaseModels.ConfigMeta{GameName: gameName, Version: 2}, Data: gameBaseModels.RTPData{RTPRate: 97}}
+		if err := store.SaveDocument(ctx, next, 5); !errorIs(err, apperr.ErrConfigVersionConflict) {
+			t.Fatalf("SaveDocument from a stale version: err = %v, want ErrConfigVersionConflict", err)
+		}
+		requireNoError(t, store.SaveDocument(ctx, next, 1), "SaveDocument from version 1")
+
+		rtp, err := store.GetGameRTP(ctx, gameName)
+		requireNoError(t, err, "GetGameRTP")
+		if rtp.Version != 2 || rtp.Data.RTPRate != 97 {
+			t.Fatalf("GetGameRTP = %+v, want version 2", rtp)
+		}
+	})
+
+	t.Run("ListGameNames", func(t *testing.T) {
+		store := newStore(t)
+		first, second := uniqueID(t, "game-a"), uniqueID(t, "game-b")
+		for _, gameName := range []string{second, first} {
+			doc := &gameBaseModels.GameConfig{ConfigMeta: gameBaseModels.ConfigMeta{GameName: gameName, Version: 1}}
+			requireNoError(t, store.SaveDocument(ctx, doc, 0), "SaveDocument")
+		}
+
+		names, err := store.ListGameNames(ctx)
+		requireNoError(t, err, "ListGameNames")
+		at := map[string]int{}
+		for i, name := range names {
+			at[name] = i + 1
+		}
+		if at[first] == 0 || at[second] == 0 || at[first] > at[second] {
+			t.Fatalf("ListGameNames = %v, want %s before %s", names, first, second)
+		}
+	})
+}
+
+func requireConfigNotFound(t *testing.T, err error) {
+	t.Helper()
+	if !strings.HasSuffix(string(apperr.CodeOf(err)), string(apperr.CodeNotFound)) {
+		t.Fatalf("err = %v, want a not-found apperr", err)
+	}
+}
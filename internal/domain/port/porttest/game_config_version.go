@@ -0,0 +1,110 @@
+package porttest
+
+import (
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+func rtpVersion(gameName string, version int64) *gameBaseModels.ConfigVersion {
+	return &gameBaseModels.ConfigVersion{
+		Kind:      gameBaseModels.ConfigKindRTPs,
+		GameName:  gameName,
+		Version:   version,
+		Author:    "porttest",
+		CreatedAt: 1700000000000 + version,
+		Document: &gameBaseModels.GameRTP{
+			ConfigMeta: gameBaseModels.ConfigMeta{GameName: gameName, Version: version},
+			Data:       gameBaseModels.RTPData{RTPRate: 90 + int(version)},
+		},
+	}
+}
+
+// GameConfigVersionStore checks a port.GameConfigVersionStore.
+func GameConfigVersionStore(t *testing.T, newStore func(t *testing.T) port.GameConfigVersionStore) {
+	t.Run("SaveVersionIsInsertOnly", func(t *testing.T) {
+		store := newStore(t)
+		gameName := uniqueID(t, "game")
+		requireNoError(t, store.SaveVersion(ctx, rtpVersion(gameName, 1)), "SaveVersion")
+
+		again := rtpVersion(gameName, 1)
+		again.Author = "someone else"
+		if err := store.SaveVersion(ctx, again); !errorIs(err, apperr.ErrConfigVersionConflict) {
+			t.Fatalf("SaveVersion of an existing version: err = %v, want ErrConfigVersionConflict", err)
+		}
+
+		got, err := store.GetVersion(ctx, gameBaseModels.ConfigKindRTPs, gameName, 1)
+		requireNoError(t, err, "GetVersion")
+		if got.Author != "porttest" || got.CreatedAt != 1700000000001 {
+			t.Fatalf("GetVersion = %+v, want the first write", got)
+		}
+		rtp, ok := got.Document.(*gameBaseModels.GameRTP)
+		if !ok || rtp.Data.RTPRate != 91 {
+			t.Fatalf("GetVersion document = %#v", got.Document)
+		}
+	})
+
+	t.Run("GetVersionMissing", func(t *testing.T) {
+		store := newStore(t)
+		gameName := uniqueID(t, "game")
+		_, err := store.GetVersion(ctx, gameBaseModels.ConfigKindRTPs, gameName, 1)
+		requireNotFound(t, err)
+
+		requireNoError(t, store.SaveVersion(ctx, rtpVersion(gameName, 1)), "SaveVersion")
+		_, err = store.GetVersion(ctx, gameBaseModels.ConfigKindRTPs, gameName, 2)
+		requireNotFound(t, err)
+		_, err = store.GetVersion(ctx, gameBaseModels.ConfigKindConfigs, gameName, 1)
+		requireNotFound(t, err)
+	})
+
+	t.Run("ListVersionsNewestFirst", func(t *testing.T) {
+		store := newStore(t)
+		gameName := uniqueID(t, "game")
+		for _, v := range []int64{2, 1, 4, 3} {
+			requireNoError(t, store.SaveVersion(ctx, rtpVersion(gameName, v)), "SaveVersion")
+		}
+
+		versions, err := store.ListVersions(ctx, gameBaseModels.ConfigKindRTPs, gameName, 3)
+		requireNoError(t, err, "ListVersions")
+		if len(versions) != 3 {
+			t.Fatalf("ListVersions returned %d versions, want 3", len(versions))
+		}
+		for i, want := range []int64{4, 3, 2} {
+			if versions[i].Version != want {
+				t.Fatalf("ListVersions[%d].Version = %d, want %d", i, versions[i].Version, want)
+			}
+		}
+
+		none, err := store.ListVersions(ctx, gameBaseModels.ConfigKindRTPs, uniqueID(t, "game"), 3)
+		requireNoError(t, err, "ListVersions of an unknown game")
+		if len(none) != 0 {
+			t.Fatalf("ListVersions of an unknown game = %d versions, want 0", len(none))
+		}
+	})
+
+	t.Run("RolloutRoundTrip", func(t *testing.T) {
+		store := newStore(t)
+		gameName := uniqueID(t, "game")
+		_, err := store.GetRollout(ctx, gameBaseModels.ConfigKindRTPs, gameName)
+		requireNotFound(t, err)
+
+		rollout := &gameBaseModels.ConfigRollout{
+			Kind: gameBaseModels.ConfigKindRTPs, GameName: gameName,
+			StableVersion: 1, CandidateVersion: 2, Percent: 10, UpdatedBy: "porttest", UpdatedAt: 1,
+		}
+		requireNoError(t, store.SaveRollout(ctx, rollout), "SaveRollout")
+		rollout = &gameBaseModels.ConfigRollout{
+			Kind: gameBaseModels.ConfigKindRTPs, GameName: gameName,
+			StableVersion: 2, Percent: 0, UpdatedBy: "porttest", UpdatedAt: 2,
+		}
+		requireNoError(t, store.SaveRollout(ctx, rollout), "second SaveRollout")
+
+		got, err := store.GetRollout(ctx, gameBaseModels.ConfigKindRTPs, gameName)
+		requireNoError(t, err, "GetRollout")
+		if *got != *rollout {
+			t.Fatalf("GetRollout = %+v, want %+v", got, rollout)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package porttest
+
+import (
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+// PlayerRepository checks a port.PlayerRepository.
+func PlayerRepository(t *testing.T, newRepo func(t *testing.T) port.PlayerRepository) {
+	t.Run("GetByIDMissing", func(t *testing.T) {
+		_, err := newRepo(t).GetByID(ctx, uniqueID(t, "player"))
+		requireNotFound(t, err)
+	})
+
+	t.Run("SaveIsUpsert", func(t *testing.T) {
+		repo := newRepo(t)
+		player := &entity.Player{PlayerID: uniqueID(t, "player"), Balance: 1000, SeatID: 1, GunID: 2, RoomID: "r1"}
+		player.MarkWalletTxApplied("tx-1")
+		requireNoError(t, repo.Save(ctx, player), "first Save")
+
+		got, err := repo.GetByID(ctx, player.PlayerID)
+		requireNoError(t, err, "GetByID")
+		if got.Balance != 1000 || got.GunID != 2 || got.RoomID != "r1" {
+			t.Fatalf("GetByID = %+v, want %+v", got, player)
+		}
+		// Reconciliation depends on the applied transfers surviving a round trip.
+		if !got.HasAppliedWalletTx("tx-1") {
+			t.Fatal("applied wallet transfers were not stored")
+		}
+
+		player.Balance = 250
+		player.RoomID = ""
+		requireNoError(t, repo.Save(ctx, player), "second Save")
+		got, err = repo.GetByID(ctx, player.PlayerID)
+		requireNoError(t, err, "GetByID")
+		if got.Balance != 250 || got.RoomID != "" {
+			t.Fatalf("GetByID = %+v, want the second save", got)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+// Package porttest holds conformance suites for the repository ports. Every
+// adapter runs the same suite from its own tests, so the MongoDB, Redis and
+// in-memory implementations are held to one behaviour: misses report
+// apperr.ErrNotFound, Save is an upsert, and so on.
+//
+// Each suite takes a constructor that is called once per subtest. It must
+// return an empty repository, or one whose existing data cannot collide
+// with the random ids the suites use.
+package porttest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+// uniqueID returns prefix followed by random hex, so suites can run against
+// shared servers.
+func uniqueID(t *testing.T, prefix string) string {
+	t.Helper()
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatalf("random id: %v", err)
+	}
+	return prefix + "-" + hex.EncodeToString(b)
+}
+
+// uniqueInt returns a random id well above any hand-made one.
+func uniqueInt(t *testing.T) int {
+	t.Helper()
+	n, err := rand.Int(rand.Reader, big.NewInt(1<<30))
+	if err != nil {
+		t.Fatalf("random id: %v", err)
+	}
+	return 1<<30 + int(n.Int64())
+}
+
+func requireNotFound(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, apperr.ErrNotFound) {
+		t.Fatalf("err = %v, want apperr.ErrNotFound", err)
+	}
+}
+
+func requireNoError(t *testing.T, err error, op string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %v", op, err)
+	}
+}
+
+var ctx = context.Background()
+
+func errorIs(err, target error) bool {
+	return errors.Is(err, target)
+}
@@ -0,0 +1,159 @@
+package porttest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+// RoomRepository checks a port.RoomRepository.
+func RoomRepository(t *testing.T, newRepo func(t *testing.T) port.RoomRepository) {
+	t.Run("GetByIDMissing", func(t *testing.T) {
+		_, err := newRepo(t).GetByID(ctx, uniqueID(t, "room"))
+		requireNotFound(t, err)
+	})
+
+	t.Run("SaveRoundTrip", func(t *testing.T) {
+		repo := newRepo(t)
+		room := &entity.Room{
+			RoomID: uniqueID(t, "room"),
+			Status: string(entity.RoomStatusOpen),
+			Players: map[string]*entity.Player{
+				"p1": {PlayerID: "p1", Balance: 500, SeatID: 2, GunID: 1},
+			},
+			FishMap: map[string]*entity.FishInstance{
+				"f1": {FishUID: "f1", FishID: 3, HP: 40},
+			},
+			Config:   entity.RoomConfig{MaxPlayers: 4, BulletTTLMs: 5000, GameName: "g", ConfigVersions: map[string]int64{"rtps": 2}},
+			RTPState: entity.RTPState{TotalBet: 100, TotalWin: 90},
+		}
+		room.NextSeq()
+		requireNoError(t, repo.Save(ctx, room), "Save")
+
+		got, err := repo.GetByID(ctx, room.RoomID)
+		requireNoError(t, err, "GetByID")
+		if got.Status != room.Status || got.Seq != 1 || got.Config.MaxPlayers != 4 || got.Config.ConfigVersions["rtps"] != 2 {
+			t.Fatalf("GetByID = %+v, want %+v", got, room)
+		}
+		if got.RTPState != room.RTPState {
+			t.Fatalf("RTPState = %+v, want %+v", got.RTPState, room.RTPState)
+		}
+		if p := got.Players["p1"]; p == nil || p.Balance != 500 || p.SeatID != 2 {
+			t.Fatalf("Players = %+v", got.Players)
+		}
+		if f := got.FishMap["f1"]; f == nil || f.HP != 40 {
+			t.Fatalf("FishMap = %+v", got.FishMap)
+		}
+	})
+
+	t.Run("SaveReplacesTheLoadedRoom", func(t *testing.T) {
+		repo := newRepo(t)
+		room := &entity.Room{
+			RoomID:  uniqueID(t, "room"),
+			Status:  string(entity.RoomStatusOpen),
+			Players: map[string]*entity.Player{"p1": {PlayerID: "p1"}, "p2": {PlayerID: "p2"}},
+			Config:  entity.RoomConfig{MaxPlayers: 4},
+		}
+		room.NextSeq()
+		requireNoError(t, repo.Save(ctx, room), "first Save")
+
+		loaded, err := repo.GetByID(ctx, room.RoomID)
+		requireNoError(t, err, "GetByID")
+		loaded.Status = string(entity.RoomStatusRunning)
+		delete(loaded.Players, "p2")
+		loaded.NextSeq()
+		requireNoError(t, repo.Save(ctx, loaded), "second Save")
+
+		got, err := repo.GetByID(ctx, room.RoomID)
+		requireNoError(t, err, "GetByID")
+		if got.Status != string(entity.RoomStatusRunning) || got.Seq != 2 {
+			t.Fatalf("GetByID = %+v, want the second save", got)
+		}
+		if len(got.Players) != 1 || !got.HasPlayer("p1") {
+			t.Fatalf("Players = %v, want only p1", got.Players)
+		}
+	})
+	t.Run("SaveRejectsAStaleRoom", func(t *testing.T) {
+		repo := newRepo(t)
+		room := &entity.Room{RoomID: uniqueID(t, "room"), Config: entity.RoomConfig{MaxPlayers: 4}}
+		room.NextSeq()
+		requireNoError(t, repo.Save(ctx, room), "Save")
+
+		first, err := repo.GetByID(ctx, room.RoomID)
+		requireNoError(t, err, "GetByID")
+		second, err := repo.GetByID(ctx, room.RoomID)
+		requireNoError(t, err, "GetByID")
+
+		first.Status = string(entity.RoomStatusRunning)
+		first.NextSeq()
+		requireNoError(t, repo.Save(ctx, first), "Save of the first copy")
+		second.Status = string(entity.RoomStatusClosed)
+		second.NextSeq()
+		if err := repo.Save(ctx, second); !errorIs(err, apperr.ErrRoomConflict) {
+			t.Fatalf("Save of the stale copy: err = %v, want ErrRoomConflict", err)
+		}
+
+		got, err := repo.GetByID(ctx, room.RoomID)
+		requireNoError(t, err, "GetByID")
+		if got.Status != string(entity.RoomStatusRunning) || got.Seq != 2 {
+			t.Fatalf("GetByID = status %q seq %d, want the first copy", got.Status, got.Seq)
+		}
+	})
+
+	t.Run("SaveRejectsASecondCreate", func(t *testing.T) {
+		repo := newRepo(t)
+		roomID := uniqueID(t, "room")
+		first := &entity.Room{RoomID: roomID, Config: entity.RoomConfig{MaxPlayers: 4}}
+		first.NextSeq()
+		requireNoError(t, repo.Save(ctx, first), "first Save")
+
+		second := &entity.Room{RoomID: roomID, Config: entity.RoomConfig{MaxPlayers: 2}}
+		second.NextSeq()
+		if err := repo.Save(ctx, second); !errorIs(err, apperr.ErrRoomConflict) {
+			t.Fatalf("second create: err = %v, want ErrRoomConflict", err)
+		}
+	})
+
+	t.Run("ListWithExpiredBullets", func(t *testing.T) {
+		repo := newRepo(t)
+		save := func(roomID string, expiresAt ...int64) {
+			room := &entity.Room{RoomID: roomID, Config: entity.RoomConfig{MaxPlayers: 4}, Bullets: map[string]*entity.Bullet{}}
+			for i, at := range expiresAt {
+				room.Bullets[entity.BulletKey("p1", fmt.Sprint(i))] = &entity.Bullet{BulletID: fmt.Sprint(i), PlayerID: "p1", ExpiresAt: at}
+			}
+			room.NextSeq()
+			requireNoError(t, repo.Save(ctx, room), "Save "+roomID)
+		}
+		due, later, idle, settled := uniqueID(t, "due"), uniqueID(t, "later"), uniqueID(t, "idle"), uniqueID(t, "settled")
+		save(due, 9000, 4000)
+		save(later, 6000)
+		save(idle)
+		save(settled, 1000)
+
+		// Settling the bullets takes the room off the list on its next save.
+		room, err := repo.GetByID(ctx, settled)
+		requireNoError(t, err, "GetByID")
+		room.ExpireBullets(1000)
+		room.NextSeq()
+		requireNoError(t, repo.Save(ctx, room), "Save of the settled room")
+
+		got, err := repo.ListWithExpiredBullets(ctx, 5000, 10)
+		requireNoError(t, err, "ListWithExpiredBullets")
+		if len(got) != 1 || got[0] != due {
+			t.Fatalf("ListWithExpiredBullets(5000) = %v, want [%s]", got, due)
+		}
+		got, err = repo.ListWithExpiredBullets(ctx, 6000, 10)
+		requireNoError(t, err, "ListWithExpiredBullets")
+		if len(got) != 2 || got[0] != due || got[1] != later {
+			t.Fatalf("ListWithExpiredBullets(6000) = %v, want [%s %s]", got, due, later)
+		}
+		got, err = repo.ListWithExpiredBullets(ctx, 6000, 1)
+		requireNoError(t, err, "ListWithExpiredBullets")
+		if len(got) != 1 || got[0] != due {
+			t.Fatalf("ListWithExpiredBullets(6000, limit 1) = %v, want [%s]", got, due)
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package porttest
+
+import (
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+// RTPRepository checks a port.RTPRepository.
+func RTPRepository(t *testing.T, newRepo func(t *testing.T) port.RTPRepository) {
+	t.Run("GetByRoomIDMissing", func(t *testing.T) {
+		_, err := newRepo(t).GetByRoomID(ctx, uniqueID(t, "room"))
+		requireNotFound(t, err)
+	})
+
+	t.Run("SaveIsUpsert", func(t *testing.T) {
+		repo := newRepo(t)
+		roomID := uniqueID(t, "room")
+		requireNoError(t, repo.Save(ctx, roomID, &entity.RTPState{TotalBet: 100, TotalWin: 40}), "first Save")
+		requireNoError(t, repo.Save(ctx, roomID, &entity.RTPState{TotalBet: 300, TotalWin: 290}), "second Save")
+
+		got, err := repo.GetByRoomID(ctx, roomID)
+		requireNoError(t, err, "GetByRoomID")
+		if *got != (entity.RTPState{TotalBet: 300, TotalWin: 290}) {
+			t.Fatalf("GetByRoomID = %+v, want the second save", got)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package porttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+// ShotResultRepository checks a port.ShotResultRepository.
+func ShotResultRepository(t *testing.T, newRepo func(t *testing.T) port.ShotResultRepository) {
+	t.Run("ClaimOnce", func(t *testing.T) {
+		repo := newRepo(t)
+		playerID, bulletID := uniqueID(t, "player"), uniqueID(t, "bullet")
+
+		claimed, result, err := repo.Claim(ctx, playerID, bulletID, time.Minute)
+		requireNoError(t, err, "Claim")
+		if !claimed || result != nil {
+			t.Fatalf("first Claim = %v, %+v; want claimed", claimed, result)
+		}
+		claimed, result, err = repo.Claim(ctx, playerID, bulletID, time.Minute)
+		requireNoError(t, err, "Claim")
+		if claimed || result != nil {
+			t.Fatalf("Claim while pending = %v, %+v; want in flight", claimed, result)
+		}
+	})
+
+	t.Run("CompleteStoresResult", func(t *testing.T) {
+		repo := newRepo(t)
+		playerID, bulletID := uniqueID(t, "player"), uniqueID(t, "bullet")
+		_, _, err := repo.Claim(ctx, playerID, bulletID, time.Minute)
+		requireNoError(t, err, "Claim")
+
+		stored := &entity.ShotResult{
+			Shot:   entity.Shot{BulletID: bulletID, PlayerID: playerID, GunID: 2},
+			RoomID: "r1",
+			Cost:   15,
+			Hit:    true,
+			Reward: 40,
+		}
+		requireNoError(t, repo.Complete(ctx, playerID, bulletID, stored, time.Minute), "Complete")
+
+		claimed, result, err := repo.Claim(ctx, playerID, bulletID, time.Minute)
+		requireNoError(t, err, "Claim")
+		if claimed || result == nil {
+			t.Fatalf("Claim after Complete = %v, %+v; want the stored result", claimed, result)
+		}
+		if result.Cost != 15 || !result.Hit || result.Reward != 40 || result.Shot.GunID != 2 {
+			t.Fatalf("stored result = %+v, want %+v", result, stored)
+		}
+	})
+
+	t.Run("ReleaseDropsPendingClaim", func(t *testing.T) {
+		repo := newRepo(t)
+		playerID, bulletID := uniqueID(t, "player"), uniqueID(t, "bullet")
+		_, _, err := repo.Claim(ctx, playerID, bulletID, time.Minute)
+		requireNoError(t, err, "Claim")
+		requireNoError(t, repo.Release(ctx, playerID, bulletID), "Release")
+
+		claimed, _, err := repo.Claim(ctx, playerID, bulletID, time.Minute)
+		requireNoError(t, err, "Claim")
+		if !claimed {
+			t.Fatal("Claim after Release was refused")
+		}
+	})
+
+	t.Run("ReleaseKeepsCompletedResult", func(t *testing.T) {
+		repo := newRepo(t)
+		playerID, bulletID := uniqueID(t, "player"), uniqueID(t, "bullet")
+		_, _, err := repo.Claim(ctx, playerID, bulletID, time.Minute)
+		requireNoError(t, err, "Claim")
+		requireNoError(t, repo.Complete(ctx, playerID, bulletID, &entity.ShotResult{Cost: 5}, time.Minute), "Complete")
+		requireNoError(t, repo.Release(ctx, playerID, bulletID), "Release")
+
+		_, result, err := repo.Claim(ctx, playerID, bulletID, time.Minute)
+		requireNoError(t, err, "Claim")
+		if result == nil {
+			t.Fatal("late Release dropped the completed result")
+		}
+	})
+
+	t.Run("ClaimExpires", func(t *testing.T) {
+		repo := newRepo(t)
+		playerID, bulletID := uniqueID(t, "player"), uniqueID(t, "bullet")
+		_, _, err := repo.Claim(ctx, playerID, bulletID, 50*time.Millisecond)
+		requireNoError(t, err, "Claim")
+
+		time.Sleep(150 * time.Millisecond)
+		claimed, _, err := repo.Claim(ctx, playerID, bulletID, time.Minute)
+		requireNoError(t, err, "Claim")
+		if !claimed {
+			t.Fatal("expired claim still blocks the bullet id")
+		}
+	})
+}
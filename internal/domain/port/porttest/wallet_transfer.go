@@ -0,0 +1,80 @@
+package porttest
+
+import (
+	"testing"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+// WalletTransferRepository checks a port.WalletTransferRepository. The
+// suite only looks at transfers updated in the distant past, so it can
+// share a collection with other data.
+func WalletTransferRepository(t *testing.T, newRepo func(t *testing.T) port.WalletTransferRepository) {
+	transfer := func(t *testing.T, status string, updatedAt int64) *entity.WalletTransfer {
+		return &entity.WalletTransfer{
+			TxID:      uniqueID(t, "tx"),
+			PlayerID:  uniqueID(t, "player"),
+			RoomID:    uniqueID(t, "room"),
+			Kind:      entity.WalletTransferBuyIn,
+			Amount:    1000,
+			Status:    status,
+			CreatedAt: updatedAt,
+			UpdatedAt: updatedAt,
+		}
+	}
+
+	t.Run("ListPendingFiltersAndOrders", func(t *testing.T) {
+		repo := newRepo(t)
+		late := transfer(t, entity.WalletTransferPending, 30)
+		early := transfer(t, entity.WalletTransferPending, 10)
+		middle := transfer(t, entity.WalletTransferPending, 20)
+		tooNew := transfer(t, entity.WalletTransferPending, 50)
+		committed := transfer(t, entity.WalletTransferCommitted, 5)
+		for _, tr := range []*entity.WalletTransfer{late, early, middle, tooNew, committed} {
+			requireNoError(t, repo.Save(ctx, tr), "Save")
+		}
+
+		pending, err := repo.ListPending(ctx, 40, 2)
+		requireNoError(t, err, "ListPending")
+		if len(pending) != 2 || pending[0].TxID != early.TxID || pending[1].TxID != middle.TxID {
+			t.Fatalf("ListPending(40, 2) = %v, want [%s %s]", txIDs(pending), early.TxID, middle.TxID)
+		}
+
+		pending, err = repo.ListPending(ctx, 40, 10)
+		requireNoError(t, err, "ListPending")
+		if len(pending) != 3 || pending[2].TxID != late.TxID {
+			t.Fatalf("ListPending(40, 10) = %v, want 3 ending with %s", txIDs(pending), late.TxID)
+		}
+		if *pending[0] != *early {
+			t.Fatalf("ListPending[0] = %+v, want %+v", pending[0], early)
+		}
+	})
+
+	t.Run("SaveIsUpsert", func(t *testing.T) {
+		repo := newRepo(t)
+		tr := transfer(t, entity.WalletTransferPending, 60)
+		requireNoError(t, repo.Save(ctx, tr), "Save")
+
+		tr.Status = entity.WalletTransferCommitted
+		tr.Attempts = 2
+		tr.UpdatedAt = 61
+		requireNoError(t, repo.Save(ctx, tr), "second Save")
+
+		pending, err := repo.ListPending(ctx, 100, 100)
+		requireNoError(t, err, "ListPending")
+		for _, p := range pending {
+			if p.TxID == tr.TxID {
+				t.Fatalf("committed transfer %s still listed as pending", tr.TxID)
+			}
+		}
+	})
+}
+
+func txIDs(transfers []*entity.WalletTransfer) []string {
+	ids := make([]string, len(transfers))
+	for i, tr := range transfers {
+		ids[i] = tr.TxID
+	}
+	return ids
+}
@@ -0,0 +1,23 @@
+package port
+
+// RNG hands out the random stream for each room. Streams are counter based:
+// draw i of a stream depends only on the stream seed and i, so any outcome
+// can be reproduced offline from its seed id and draw index.
+type RNG interface {
+	// Stream returns the stream for a room, seeding it on first use.
+	Stream(roomID string) RandomStream
+
+	// ReleaseRoom retires the room's stream. The next Stream call for the
+	// room seeds a new one.
+	RoomReleaser
+
+	// ReleaseAll retires every stream, e.g. when the instance shuts down.
+	ReleaseAll()
+}
+
+// RandomStream is a sequence of uniform draws in [0, 1). It is safe for
+// concurrent use; each call to Draw consumes exactly one index.
+type RandomStream interface {
+	SeedID() string
+	Draw() (value float64, index uint64)
+}
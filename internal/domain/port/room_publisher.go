@@ -0,0 +1,10 @@
+package port
+
+import "github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+
+// RoomPublisher pushes the events of a saved room mutation to the clients
+// watching the room. seq is the room sequence the mutation was saved under,
+// so clients can drop anything a later snapshot already reflects.
+type RoomPublisher interface {
+	Publish(roomID string, seq int64, events []*entity.GameEvent)
+}
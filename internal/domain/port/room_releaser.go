@@ -0,0 +1,8 @@
+package port
+
+// RoomReleaser drops what an instance keeps in process for a room once the
+// room has closed, which happens when its last player leaves. A room that is
+// joined again afterwards starts over.
+type RoomReleaser interface {
+	ReleaseRoom(roomID string)
+}
@@ -8,5 +8,15 @@ import (
 
 type RoomRepository interface {
 	GetByID(ctx context.Context, roomID string) (*entity.Room, error)
+
+	// Save writes a room whose Seq was advanced once by NextSeq since it was
+	// loaded. The stored room is replaced only if it is still at the Seq
+	// before that, and a room saved at Seq 1 is created only if none exists;
+	// otherwise another request saved the room first and Save returns
+	// apperr.ErrRoomConflict.
 	Save(ctx context.Context, room *entity.Room) error
+
+	// ListWithExpiredBullets returns the ids of up to limit rooms holding a
+	// bullet that expired at or before nowMs, as recorded by their last save.
+	ListWithExpiredBullets(ctx context.Context, nowMs int64, limit int) ([]string, error)
 }
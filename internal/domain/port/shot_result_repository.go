@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+// ShotResultRepository deduplicates shots by client bullet id across server
+// instances.
+type ShotResultRepository interface {
+	// Claim reserves a bullet id for processing. When the id is already
+	// known it returns claimed=false along with the stored result, or a nil
+	// result if another request is still processing it.
+	Claim(ctx context.Context, playerID, bulletID string, ttl time.Duration) (claimed bool, result *entity.ShotResult, err error)
+
+	// Complete stores the result for a claimed bullet id.
+	Complete(ctx context.Context, playerID, bulletID string, result *entity.ShotResult, ttl time.Duration) error
+
+	// Release drops an unfinished claim so the client can retry.
+	Release(ctx context.Context, playerID, bulletID string) error
+}
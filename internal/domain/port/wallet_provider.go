@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+// WalletProvider is the operator's seamless wallet holding the player's real
+// funds. Every mutating call carries a transaction id and must be idempotent
+// on it, so callers can safely retry after a timeout. A rolled back
+// transaction id is spent: Debit and Credit return
+// apperr.ErrWalletTxRolledBack for it. Implementations return
+// apperr errors for definitive rejections (e.g. insufficient funds); any
+// other error is treated as transient.
+type WalletProvider interface {
+	GetBalance(ctx context.Context, playerID string) (int64, error)
+	Debit(ctx context.Context, playerID, txID string, amount int64) (int64, error)
+	Credit(ctx context.Context, playerID, txID string, amount int64) (int64, error)
+	Rollback(ctx context.Context, playerID, txID string) error
+}
+
+// WalletTransferRepository records buy-ins and cash-outs so transfers whose
+// outcome is unknown can be reconciled later.
+type WalletTransferRepository interface {
+	Save(ctx context.Context, transfer *entity.WalletTransfer) error
+	ListPending(ctx context.Context, updatedBefore int64, limit int) ([]*entity.WalletTransfer, error)
+}
@@ -3,17 +3,30 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 type Config struct {
 	Server ServerConfig
 	Mongo  MongoConfig
 	Redis  RedisConfig
+	Auth   AuthConfig
+	Wallet WalletConfig
+	RNG    RNGConfig
+	Shoot  ShootConfig
+
+	GameConfig GameConfigConfig
+	Tracing    TracingConfig
+	Log        LogConfig
+	Health     HealthConfig
+	Drivers    DriverConfig
+	Storage    StorageConfig
 }
 
 type ServerConfig struct {
-	Port int
-	Host string
+	Port            int
+	Host            string
+	ShutdownTimeout int // seconds a graceful shutdown may take before the process exits anyway
 }
 
 type MongoConfig struct {
@@ -33,11 +46,85 @@ type RedisConfig struct {
 	RetryDelay int // milliseconds
 }
 
+type AuthConfig struct {
+	JWTSecret string // HMAC key used to verify access tokens
+}
+
+type WalletConfig struct {
+	Provider          string // "http" for the operator seamless wallet, "memory" for local dev
+	AllowMemory       bool   // lets the memory wallet run on persistent storage, e.g. a shared test environment
+	BaseURL           string
+	APIKey            string
+	TimeoutMs         int
+	DevBalance        int64 // starting balance for unknown players in the memory wallet
+	ReconcileInterval int   // seconds between pending transfer reconciliation runs
+}
+
+type RNGConfig struct {
+	Mode string // "crypto" in production, "seeded" for reproducible test runs
+	Seed int64  // master seed for the seeded mode
+}
+
+type ShootConfig struct {
+	SweepIntervalMs int // milliseconds between settling expired bullets in idle rooms; 0 disables the sweep
+}
+
+type GameConfigConfig struct {
+	ValidateOnStart string // "warn" logs violations, "strict" refuses to start, "off" skips the check
+	LocalCacheSize  int    // entries kept in the in-process config cache; 0 disables it
+	LocalCacheTTL   int    // seconds an in-process entry is served before it must be reloaded
+
+	CacheTTLs        map[string]int // Redis TTL in seconds per config kind; other kinds use Redis.CacheTTL
+	NegativeCacheTTL int            // seconds a game without a document is remembered; 0 disables it
+}
+
+type TracingConfig struct {
+	Exporter     string  // "otlp" to send spans to a collector, "stdout" to print them in dev, "none" to disable
+	OTLPEndpoint string  // host:port of the collector's OTLP/HTTP receiver
+	OTLPInsecure bool    // send to the collector over plain HTTP
+	ServiceName  string  // service.name reported on every span
+	SampleRatio  float64 // share of new traces recorded, 0 to 1
+}
+
+type LogConfig struct {
+	Level                string // "debug", "info", "warn" or "error"
+	ShotSampleFirst      int    // shot log lines of each kind written per second before sampling
+	ShotSampleThereafter int    // after that, one line in this many is written; 0 drops the rest
+}
+
+type HealthConfig struct {
+	CheckTimeoutMs int // per-dependency ping timeout of the readiness probe
+	DrainDelay     int // seconds between failing readiness and closing the listener on shutdown
+}
+
+// DriverConfig names the factory that builds each infrastructure component.
+type DriverConfig struct {
+	Database string // "mongo" or "memory"; defaults to "memory" when STORAGE=memory
+	Cache    string // "redis" or "memory"; defaults to "memory" when STORAGE=memory
+	Server   string // "fiber"
+}
+
+type StorageConfig struct {
+	Mode     string // "mongo" keeps data in MongoDB and Redis, "memory" in process with no external services
+	Fixtures string // JSON file seeded into memory storage on start; empty starts empty
+}
+
 func Load() *Config {
+	storage := getEnv("STORAGE", "mongo")
+	databaseDriver, cacheDriver := "mongo", "redis"
+	// The memory wallet hands new players free chips, so it is only the
+	// default when nothing is persisted anyway.
+	walletProvider := "http"
+	if storage == "memory" {
+		databaseDriver, cacheDriver = "memory", "memory"
+		walletProvider = "memory"
+	}
+
 	return &Config{
 		Server: ServerConfig{
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
-			Port: getEnvInt("SERVER_PORT", 8080),
+			Host:            getEnv("SERVER_HOST", "0.0.0.0"),
+			Port:            getEnvInt("SERVER_PORT", 8080),
+			ShutdownTimeout: getEnvInt("SERVER_SHUTDOWN_TIMEOUT", 30),
 		},
 		Mongo: MongoConfig{
 			URI:        getEnv("MONGO_URI", "mongodb://localhost:27017"),
@@ -54,6 +141,58 @@ func Load() *Config {
 			MaxRetries: getEnvInt("REDIS_MAX_RETRIES", 5),
 			RetryDelay: getEnvInt("REDIS_RETRY_DELAY", 1000),
 		},
+		Auth: AuthConfig{
+			JWTSecret: getEnv("AUTH_JWT_SECRET", ""),
+		},
+		Wallet: WalletConfig{
+			Provider:          getEnv("WALLET_PROVIDER", walletProvider),
+			AllowMemory:       getEnvBool("WALLET_ALLOW_MEMORY", false),
+			BaseURL:           getEnv("WALLET_BASE_URL", ""),
+			APIKey:            getEnv("WALLET_API_KEY", ""),
+			TimeoutMs:         getEnvInt("WALLET_TIMEOUT_MS", 3000),
+			DevBalance:        int64(getEnvInt("WALLET_DEV_BALANCE", 100000)),
+			ReconcileInterval: getEnvInt("WALLET_RECONCILE_INTERVAL", 60),
+		},
+		RNG: RNGConfig{
+			Mode: getEnv("RNG_MODE", "crypto"),
+			Seed: int64(getEnvInt("RNG_SEED", 1)),
+		},
+		Shoot: ShootConfig{
+			SweepIntervalMs: getEnvInt("SHOOT_SWEEP_INTERVAL_MS", 1000),
+		},
+		GameConfig: GameConfigConfig{
+			ValidateOnStart: getEnv("GAME_CONFIG_VALIDATE_ON_START", "warn"),
+			LocalCacheSize:  getEnvInt("GAME_CONFIG_LOCAL_CACHE_SIZE", 512),
+			LocalCacheTTL:   getEnvInt("GAME_CONFIG_LOCAL_CACHE_TTL", 60),
+
+			CacheTTLs:        getEnvIntMap("GAME_CONFIG_CACHE_TTLS"),
+			NegativeCacheTTL: getEnvInt("GAME_CONFIG_NEGATIVE_CACHE_TTL", 30),
+		},
+		Tracing: TracingConfig{
+			Exporter:     getEnv("TRACING_EXPORTER", "none"),
+			OTLPEndpoint: getEnv("TRACING_OTLP_ENDPOINT", "localhost:4318"),
+			OTLPInsecure: getEnvBool("TRACING_OTLP_INSECURE", false),
+			ServiceName:  getEnv("TRACING_SERVICE_NAME", "fishing-gameplay"),
+			SampleRatio:  getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		Log: LogConfig{
+			Level:                getEnv("LOG_LEVEL", "info"),
+			ShotSampleFirst:      getEnvInt("LOG_SHOT_SAMPLE_FIRST", 20),
+			ShotSampleThereafter: getEnvInt("LOG_SHOT_SAMPLE_THEREAFTER", 100),
+		},
+		Health: HealthConfig{
+			CheckTimeoutMs: getEnvInt("HEALTH_CHECK_TIMEOUT_MS", 1000),
+			DrainDelay:     getEnvInt("HEALTH_DRAIN_DELAY", 5),
+		},
+		Drivers: DriverConfig{
+			Database: getEnv("DATABASE_DRIVER", databaseDriver),
+			Cache:    getEnv("CACHE_DRIVER", cacheDriver),
+			Server:   getEnv("SERVER_DRIVER", "fiber"),
+		},
+		Storage: StorageConfig{
+			Mode:     storage,
+			Fixtures: getEnv("STORAGE_FIXTURES", ""),
+		},
 	}
 }
 
@@ -112,6 +251,16 @@ func (c *Config) GetRedisRetryDelay() int {
 	return c.Redis.RetryDelay
 }
 
+// Auth configuration methods
+func (c *Config) GetAuthJWTSecret() string {
+	return c.Auth.JWTSecret
+}
+
+// Storage configuration methods
+func (c *Config) GetStorageFixtures() string {
+	return c.Storage.Fixtures
+}
+
 func getEnv(key string, defaultVal string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
@@ -126,3 +275,35 @@ func getEnvInt(key string, defaultVal int) int {
 	}
 	return defaultVal
 }
+
+func getEnvBool(key string, defaultVal bool) bool {
+	valStr := getEnv(key, "")
+	if val, err := strconv.ParseBool(valStr); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
+func getEnvFloat(key string, defaultVal float64) float64 {
+	valStr := getEnv(key, "")
+	if val, err := strconv.ParseFloat(valStr, 64); err == nil {
+		return val
+	}
+	return defaultVal
+}
+
+// getEnvIntMap parses a comma-separated list of key=int pairs, such as
+// "rtps=300,types=3600". Malformed pairs are skipped.
+func getEnvIntMap(key string) map[string]int {
+	result := map[string]int{}
+	for _, pair := range strings.Split(getEnv(key, ""), ",") {
+		name, valStr, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || name == "" {
+			continue
+		}
+		if val, err := strconv.Atoi(strings.TrimSpace(valStr)); err == nil {
+			result[strings.TrimSpace(name)] = val
+		}
+	}
+	return result
+}
@@ -20,4 +20,10 @@ type Config interface {
 	GetRedisCacheTTL() int
 	GetRedisMaxRetries() int
 	GetRedisRetryDelay() int
+
+	// Auth configuration
+	GetAuthJWTSecret() string
+
+	// Storage configuration
+	GetStorageFixtures() string
 }
@@ -1,13 +1,19 @@
 package contract
 
+import "context"
+
 // Server defines the interface for HTTP server operations
 type Server interface {
-	// Start starts the HTTP server
+	// Start starts the HTTP server and blocks until it stops
 	Start() error
 
 	// Stop stops the HTTP server
 	Stop() error
 
+	// Shutdown stops accepting connections and waits for in-flight requests
+	// to finish, or until ctx ends
+	Shutdown(ctx context.Context) error
+
 	// GetNative returns the native HTTP framework instance
 	GetNative() interface{}
 }
@@ -0,0 +1,123 @@
+// Package health checks whether the server's dependencies are reachable,
+// for the readiness probe.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp           = "up"
+	StatusDown         = "down"
+	StatusReady        = "ready"
+	StatusNotReady     = "not_ready"
+	StatusShuttingDown = "shutting_down"
+)
+
+// Pinger is a dependency that can report whether it is reachable, such as
+// contract.Database or contract.Cache.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// DependencyStatus is the outcome of pinging one dependency.
+type DependencyStatus struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the readiness of the server and of each dependency.
+type Report struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyStatus `json:"dependencies,omitempty"`
+}
+
+// Ready reports whether the server should receive traffic.
+func (r Report) Ready() bool {
+	return r.Status == StatusReady
+}
+
+// Checker pings registered dependencies in parallel, each bounded by
+// timeout. Once shutdown has begun it reports not ready without pinging, so
+// load balancers stop routing new traffic while requests drain.
+type Checker struct {
+	timeout      time.Duration
+	names        []string
+	deps         map[string]Pinger
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		deps:    map[string]Pinger{},
+	}
+}
+
+// Register adds a dependency checked under name. It is not safe to call
+// once the checker is serving probes.
+func (c *Checker) Register(name string, dep Pinger) {
+	if _, exists := c.deps[name]; !exists {
+		c.names = append(c.names, name)
+	}
+	c.deps[name] = dep
+}
+
+// SetShuttingDown makes every later readiness check fail.
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+// ShuttingDown reports whether SetShuttingDown has been called.
+func (c *Checker) ShuttingDown() bool {
+	return c.shuttingDown.Load()
+}
+
+// Readiness pings every dependency and reports the server ready only if all
+// of them answered within the timeout.
+func (c *Checker) Readiness(ctx context.Context) Report {
+	if c.ShuttingDown() {
+		return Report{Status: StatusShuttingDown}
+	}
+
+	statuses := make([]DependencyStatus, len(c.names))
+	var wg sync.WaitGroup
+	for i, name := range c.names {
+		wg.Add(1)
+		go func(i int, dep Pinger) {
+			defer wg.Done()
+			statuses[i] = c.ping(ctx, dep)
+		}(i, c.deps[name])
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusReady, Dependencies: make(map[string]DependencyStatus, len(c.names))}
+	for i, name := range c.names {
+		report.Dependencies[name] = statuses[i]
+		if statuses[i].Status != StatusUp {
+			report.Status = StatusNotReady
+		}
+	}
+	return report
+}
+
+func (c *Checker) ping(ctx context.Context, dep Pinger) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dep.Ping(ctx)
+	status := DependencyStatus{
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		status.Status = StatusDown
+		status.Error = err.Error()
+	}
+	return status
+}
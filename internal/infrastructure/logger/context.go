@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying l, which FromContext returns.
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// With returns a copy of ctx whose logger also writes fields, such as the
+// request id or the room a call is about.
+func With(ctx context.Context, fields ...zap.Field) context.Context {
+	return NewContext(ctx, fromContext(ctx).With(fields...))
+}
+
+// FromContext returns the logger carried by ctx, or the global logger, with
+// the trace and span ids of the span in ctx so log lines can be matched to
+// their trace.
+func FromContext(ctx context.Context) *zap.Logger {
+	return Annotate(ctx, fromContext(ctx))
+}
+
+func fromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return Get()
+}
+
+// Annotate adds the trace and span ids of the span in ctx to l. l is
+// returned unchanged when ctx carries no span.
+func Annotate(ctx context.Context, l *zap.Logger) *zap.Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+	return l.With(
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+	)
+}
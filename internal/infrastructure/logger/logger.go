@@ -7,10 +7,15 @@ import (
 
 var globalLogger *zap.Logger
 
-func Init() error {
+// Init builds the global logger writing at level ("debug", "info", "warn",
+// "error"). An unknown level falls back to info.
+func Init(level string) error {
 	config := zap.NewProductionConfig()
 	config.EncoderConfig.TimeKey = "timestamp"
 	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	if lvl, err := zapcore.ParseLevel(level); err == nil {
+		config.Level = zap.NewAtomicLevelAt(lvl)
+	}
 
 	var err error
 	globalLogger, err = config.Build()
@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// Sampler thins out high-volume log lines. Within each tick it lets the
+// first entries of a message through, then every thereafter-th one, so a
+// busy room still shows up in the logs without flooding them.
+type Sampler struct {
+	tick       time.Duration
+	first      uint64
+	thereafter uint64
+
+	mu      sync.Mutex
+	counts  map[string]uint64
+	resetAt time.Time
+	now     func() time.Time
+}
+
+// NewSampler returns a Sampler that allows first entries per message each
+// tick and every thereafter-th after that; thereafter <= 0 drops the rest.
+func NewSampler(tick time.Duration, first, thereafter int) *Sampler {
+	if first < 0 {
+		first = 0
+	}
+	if thereafter < 0 {
+		thereafter = 0
+	}
+	return &Sampler{
+		tick:       tick,
+		first:      uint64(first),
+		thereafter: uint64(thereafter),
+		counts:     map[string]uint64{},
+		now:        time.Now,
+	}
+}
+
+// Allow reports whether the next entry for msg should be written. A nil
+// Sampler allows everything.
+func (s *Sampler) Allow(msg string) bool {
+	if s == nil {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now := s.now(); !now.Before(s.resetAt) {
+		s.counts = map[string]uint64{}
+		s.resetAt = now.Add(s.tick)
+	}
+	s.counts[msg]++
+	n := s.counts[msg]
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
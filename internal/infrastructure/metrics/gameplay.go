@@ -0,0 +1,35 @@
+package metrics
+
+import "strconv"
+
+// Gameplay counts shots into the gameplay metrics.
+type Gameplay struct{}
+
+func (Gameplay) ShotFired(game string, gunID int, bet int64) {
+	game = gameLabel(game)
+	ShotsFired.WithLabelValues(game, strconv.Itoa(gunID)).Inc()
+	BetAmount.WithLabelValues(game).Add(float64(bet))
+}
+
+func (Gameplay) ShotResolved(game string, hit, killed bool, win int64) {
+	game = gameLabel(game)
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	ShotResults.WithLabelValues(game, result).Inc()
+	if killed {
+		FishKilled.WithLabelValues(game).Inc()
+	}
+	if win > 0 {
+		WinAmount.WithLabelValues(game).Add(float64(win))
+	}
+}
+
+// gameLabel is the game label of rooms without a game config.
+func gameLabel(game string) string {
+	if game == "" {
+		return "unknown"
+	}
+	return game
+}
@@ -0,0 +1,256 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "fishing"
+
+// Registry holds every metric the server exports on /metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	ShotsFired = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "shots_fired_total",
+		Help:      "Bullets fired, by game and gun.",
+	}, []string{"game", "gun"})
+
+	ShotResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "shot_results_total",
+		Help:      "Resolved shots, by game and result (hit or miss).",
+	}, []string{"game", "result"})
+
+	FishKilled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "fish_killed_total",
+		Help:      "Fish killed, by game. Divide by shot_results_total for the kill rate.",
+	}, []string{"game"})
+
+	BetAmount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bet_amount_total",
+		Help:      "Credits bet on shots, by game.",
+	}, []string{"game"})
+
+	WinAmount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "win_amount_total",
+		Help:      "Credits won from kills, by game.",
+	}, []string{"game"})
+
+	WSConnections = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "ws_connections",
+		Help:      "Open room websocket connections on this instance.",
+	})
+
+	RepositoryCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "repository_call_duration_seconds",
+		Help:      "Repository call latency by backend, method and outcome.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"backend", "method", "outcome"})
+
+	roomRTP = newRoomRTPCollector(10 * time.Minute)
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		ShotsFired,
+		ShotResults,
+		FishKilled,
+		BetAmount,
+		WinAmount,
+		WSConnections,
+		RepositoryCallDuration,
+		roomRTP,
+	)
+}
+
+// Handler serves the registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// SetRoomRTP records the live RTP of a room from its running bet and win
+// totals.
+func SetRoomRTP(roomID string, totalBet, totalWin int64) {
+	roomRTP.set(roomID, totalBet, totalWin)
+}
+
+// roomRTPCollector exports one RTP gauge per room, dropping rooms that have
+// not been updated within idle so finished rooms do not accumulate.
+type roomRTPCollector struct {
+	desc *prometheus.Desc
+	idle time.Duration
+
+	mu    sync.Mutex
+	rooms map[string]roomRTPValue
+	now   func() time.Time
+}
+
+type roomRTPValue struct {
+	rtp       float64
+	updatedAt time.Time
+}
+
+func newRoomRTPCollector(idle time.Duration) *roomRTPCollector {
+	return &roomRTPCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "room_rtp_ratio"),
+			"Live return to player of a room: total win over total bet.",
+			[]string{"room_id"}, nil,
+		),
+		idle:  idle,
+		rooms: map[string]roomRTPValue{},
+		now:   time.Now,
+	}
+}
+
+func (c *roomRTPCollector) set(roomID string, totalBet, totalWin int64) {
+	if totalBet <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rooms[roomID] = roomRTPValue{rtp: float64(totalWin) / float64(totalBet), updatedAt: c.now()}
+}
+
+func (c *roomRTPCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *roomRTPCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cutoff := c.now().Add(-c.idle)
+	for roomID, v := range c.rooms {
+		if v.updatedAt.Before(cutoff) {
+			delete(c.rooms, roomID)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, v.rtp, roomID)
+	}
+}
+
+// RegisterActivity exports the number of rooms with seated players and of
+// seated players, counted by count. Counts are cached for ttl so frequent
+// scrapes do not each query the database.
+func RegisterActivity(count func(ctx context.Context) (rooms, players int64, err error), ttl time.Duration) {
+	Registry.MustRegister(&activityCollector{
+		roomsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "active_rooms"),
+			"Rooms with at least one seated player.", nil, nil,
+		),
+		playersDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "active_players"),
+			"Players seated in a room.", nil, nil,
+		),
+		count: count,
+		ttl:   ttl,
+	})
+}
+
+type activityCollector struct {
+	roomsDesc   *prometheus.Desc
+	playersDesc *prometheus.Desc
+	count       func(ctx context.Context) (rooms, players int64, err error)
+	ttl         time.Duration
+
+	mu        sync.Mutex
+	rooms     int64
+	players   int64
+	fetchedAt time.Time
+}
+
+func (c *activityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.roomsDesc
+	ch <- c.playersDesc
+}
+
+func (c *activityCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if time.Since(c.fetchedAt) >= c.ttl {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		rooms, players, err := c.count(ctx)
+		cancel()
+		if err != nil {
+			// Report nothing rather than a stale or zero count.
+			return
+		}
+		c.rooms, c.players, c.fetchedAt = rooms, players, time.Now()
+	}
+	ch <- prometheus.MustNewConstMetric(c.roomsDesc, prometheus.GaugeValue, float64(c.rooms))
+	ch <- prometheus.MustNewConstMetric(c.playersDesc, prometheus.GaugeValue, float64(c.players))
+}
+
+// CacheStats is one config kind's lookup counts as reported by a cache.
+type CacheStats struct {
+	LocalHits    int64
+	RedisHits    int64
+	NegativeHits int64
+	Misses       int64
+	StaleServed  int64
+	Errors       int64
+}
+
+// RegisterConfigCache exports the lookup counters reported by stats, by
+// config kind and result. The hit ratio is the non-miss share of
+// fishing_config_cache_lookups_total.
+func RegisterConfigCache(stats func() map[string]CacheStats) {
+	Registry.MustRegister(&configCacheCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "config_cache_lookups_total"),
+			"Game config cache lookups by kind and result.",
+			[]string{"kind", "result"}, nil,
+		),
+		stats: stats,
+	})
+}
+
+type configCacheCollector struct {
+	desc  *prometheus.Desc
+	stats func() map[string]CacheStats
+}
+
+func (c *configCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *configCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	for kind, s := range c.stats() {
+		for result, n := range map[string]int64{
+			"local_hit":    s.LocalHits,
+			"redis_hit":    s.RedisHits,
+			"negative_hit": s.NegativeHits,
+			"miss":         s.Misses,
+			"stale":        s.StaleServed,
+			"error":        s.Errors,
+		} {
+			ch <- prometheus.MustNewConstMetric(c.desc, prometheus.CounterValue, float64(n), kind, result)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// eventBatch collects the events produced by one persisted mutation so they
+// are appended together, after the state change they describe is saved.
+type eventBatch struct {
+	room   *entity.Room
+	roomID string
+	at     int64
+	events []*entity.GameEvent
+}
+
+func newEventBatch(room *entity.Room, now time.Time) *eventBatch {
+	return &eventBatch{room: room, roomID: room.RoomID, at: now.UnixMilli()}
+}
+
+func (b *eventBatch) add(e *entity.GameEvent) {
+	e.At = b.at
+	b.events = append(b.events, e)
+}
+
+// balance records a player's balance after a change of delta.
+func (b *eventBatch) balance(player *entity.Player, delta int64, reason string) {
+	if delta == 0 {
+		return
+	}
+	b.add(&entity.GameEvent{
+		Type:     entity.EventBalanceChanged,
+		PlayerID: player.PlayerID,
+		Amount:   delta,
+		Balance:  player.Balance,
+		Reason:   reason,
+	})
+}
+
+// settled records bullets removed by expiry or a player leaving, and the
+// refunds the room's policy credited for them.
+func (b *eventBatch) settled(bullets []*entity.Bullet) {
+	refunds := map[string]int64{}
+	for _, bullet := range bullets {
+		e := &entity.GameEvent{
+			Type:     entity.EventBulletSettled,
+			PlayerID: bullet.PlayerID,
+			Bullet:   bullet,
+		}
+		if b.room.Config.RefundsExpiredBullets() {
+			e.Amount = bullet.Cost
+			refunds[bullet.PlayerID] += bullet.Cost
+		}
+		b.add(e)
+	}
+	for playerID, amount := range refunds {
+		if p, ok := b.room.Players[playerID]; ok {
+			b.balance(p, amount, entity.BalanceReasonRefund)
+		}
+	}
+}
+
+// flush appends the batch and then publishes it, stamping each event with
+// the room sequence the mutation was saved under, so clients are only told
+// about events the log holds. Batches with no room behind them, such as
+// skill use, carry no sequence and are only appended. The state the batch
+// describes is already saved, so a failed append is logged rather than
+// failing a request whose mutation stands; the batch is then not published
+// and clients resync on the seq gap.
+func (b *eventBatch) flush(ctx context.Context, store port.EventStore, publisher port.RoomPublisher) {
+	if len(b.events) == 0 {
+		return
+	}
+	if b.room != nil {
+		for _, e := range b.events {
+			e.RoomSeq = b.room.Seq
+		}
+	}
+	if err := store.Append(ctx, b.roomID, b.events...); err != nil {
+		logger.FromContext(ctx).Error("Room events not recorded",
+			zap.String("room_id", b.roomID),
+			zap.Int("events", len(b.events)),
+			zap.Error(err),
+		)
+		return
+	}
+	if b.room != nil {
+		publisher.Publish(b.roomID, b.room.Seq, b.events)
+	}
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/sevices"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
+)
+
+const maxClientSeedLength = 64
+
+// startFairSession commits to a fresh server seed for the player's stay in a
+// provably-fair room. Any session left unrevealed by an earlier stay is
+// revealed first, at the nonce the player record last saved, so its shots
+// stay verifiable.
+func (uc *RoomUsecase) startFairSession(ctx context.Context, roomID, playerID, clientSeed string, lastNonce uint64) (*entity.FairSession, error) {
+	if err := uc.revealFairSession(ctx, roomID, playerID, lastNonce); err != nil {
+		return nil, err
+	}
+
+	serverSeed, err := uc.randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	if clientSeed == "" {
+		if clientSeed, err = uc.randomHex(16); err != nil {
+			return nil, err
+		}
+	}
+
+	now := uc.now()
+	session := &entity.FairSession{
+		SessionID:      fmt.Sprintf("%s:%s:%d", roomID, playerID, now.UnixNano()),
+		RoomID:         roomID,
+		PlayerID:       playerID,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: gameBaseSevices.HashServerSeed(serverSeed),
+		ClientSeed:     clientSeed,
+		CreatedAt:      now.Unix(),
+	}
+	if err := uc.fairSessionRepo.Save(ctx, session); err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("Fair session started",
+		zap.String("session_id", session.SessionID),
+		zap.String("server_seed_hash", session.ServerSeedHash),
+	)
+	return session, nil
+}
+
+// revealFairSession discloses the server seed of the player's active session
+// in a room and records nonce, the seat's next nonce, on it.
+func (uc *RoomUsecase) revealFairSession(ctx context.Context, roomID, playerID string, nonce uint64) error {
+	session, err := uc.fairSessionRepo.GetActive(ctx, roomID, playerID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	session.Nonce = nonce
+	session.RevealedAt = uc.now().Unix()
+	if err := uc.fairSessionRepo.Save(ctx, session); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Fair session revealed", zap.String("session_id", session.SessionID))
+	return nil
+}
+
+func (uc *RoomUsecase) randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(uc.entropy, b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// fairStream serves a single provably-fair draw for the seat's nonce, so a
+// hit roll can go through the same recordingRoller as the room RNG.
+type fairStream struct {
+	session *entity.FairSession
+	nonce   uint64
+}
+
+func (s *fairStream) SeedID() string {
+	return s.session.ServerSeedHash
+}
+
+func (s *fairStream) Draw() (float64, uint64) {
+	return gameBaseSevices.FairRoll(s.session.ServerSeed, s.session.ClientSeed, s.nonce), s.nonce
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/sevices"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type FairnessUsecase struct {
+	roomRepo    port.RoomRepository
+	sessionRepo port.FairSessionRepository
+	shotRepo    port.FairShotRepository
+}
+
+func NewFairnessUsecase(roomRepo port.RoomRepository, sessionRepo port.FairSessionRepository, shotRepo port.FairShotRepository) *FairnessUsecase {
+	return &FairnessUsecase{
+		roomRepo:    roomRepo,
+		sessionRepo: sessionRepo,
+		shotRepo:    shotRepo,
+	}
+}
+
+// ActiveSession returns the caller's current commitment in a room: the
+// server seed hash, client seed and next nonce, without the server seed.
+// The next nonce is read from the caller's seat, where shots advance it.
+func (uc *FairnessUsecase) ActiveSession(ctx context.Context, roomID, playerID string) (*entity.FairSession, error) {
+	if roomID == "" {
+		return nil, apperr.ErrInvalidRoomID
+	}
+	session, err := uc.sessionRepo.GetActive(ctx, roomID, playerID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrFairSessionNotFound
+		}
+		return nil, err
+	}
+
+	room, err := uc.roomRepo.GetByID(ctx, roomID)
+	if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		if seat, ok := room.Players[playerID]; ok {
+			session.Nonce = seat.FairNonce
+		}
+	}
+	return session.Public(), nil
+}
+
+// Session returns a session by id. The server seed is included only after
+// the session has been revealed.
+func (uc *FairnessUsecase) Session(ctx context.Context, sessionID string) (*entity.FairSession, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrFairSessionNotFound
+		}
+		return nil, err
+	}
+	return session.Public(), nil
+}
+
+// Verify recomputes a recorded shot from the revealed server seed, the client
+// seed and the nonce, and reports whether it matches what was paid.
+func (uc *FairnessUsecase) Verify(ctx context.Context, sessionID string, nonce uint64) (*entity.FairVerification, error) {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrFairSessionNotFound
+		}
+		return nil, err
+	}
+	if !session.IsRevealed() {
+		return nil, apperr.ErrSeedNotRevealed
+	}
+
+	shot, err := uc.shotRepo.Get(ctx, sessionID, nonce)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrFairShotNotFound
+		}
+		return nil, err
+	}
+
+	value := gameBaseSevices.FairRoll(session.ServerSeed, session.ClientSeed, nonce)
+	outcome := gameBaseSevices.ResolveHit(gameBaseSevices.RollerFunc(func() float64 { return value }), gameBaseModels.HitInput{
+		HitRate: shot.HitRate,
+		Damage:  shot.Damage,
+		HP:      shot.HP,
+		Payout:  shot.Payout,
+	})
+
+	return &entity.FairVerification{
+		Session: session.Public(),
+		Shot:    shot,
+		Value:   value,
+		Hit:     outcome.Hit,
+		Killed:  outcome.Killed,
+		Reward:  outcome.Payout,
+		Match: value == shot.Value && outcome.Hit == shot.Hit &&
+			outcome.Killed == shot.Killed && outcome.Payout == shot.Reward,
+	}, nil
+}
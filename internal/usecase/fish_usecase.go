@@ -7,24 +7,40 @@ import (
 
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
 	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
 )
 
 type FishUsecase struct {
-	roomRepo port.RoomRepository
-	fishRepo port.FishRepository
-	now      func() time.Time
+	roomRepo  port.RoomRepository
+	fishRepo  port.FishRepository
+	configs   *pinnedConfigs
+	events    port.EventStore
+	publisher port.RoomPublisher
+	now       func() time.Time
 }
 
-func NewFishUsecase(roomRepo port.RoomRepository, fishRepo port.FishRepository) *FishUsecase {
+func NewFishUsecase(roomRepo port.RoomRepository, fishRepo port.FishRepository, configStore port.GameConfigStore, configVersions port.GameConfigVersionStore, events port.EventStore, opts ...Option) *FishUsecase {
+	o := newOptions(opts)
 	return &FishUsecase{
-		roomRepo: roomRepo,
-		fishRepo: fishRepo,
-		now:      time.Now,
+		roomRepo:  roomRepo,
+		fishRepo:  fishRepo,
+		configs:   newPinnedConfigs(configStore, configVersions),
+		events:    events,
+		publisher: o.publisher,
+		now:       o.now,
 	}
 }
 
-func (uc *FishUsecase) SpawnFish(ctx context.Context, roomID string, fishID int, fishUID string, pathID int) (*entity.FishInstance, error) {
+func (uc *FishUsecase) SpawnFish(ctx context.Context, roomID string, fishID int, fishUID string, pathID int) (_ *entity.FishInstance, err error) {
+	ctx = logContext(ctx, roomID, "")
+	defer func() {
+		if err != nil {
+			logFailure(ctx, "Fish spawn rejected", err, zap.String("fish_uid", fishUID), zap.Int("fish_id", fishID))
+		}
+	}()
+
 	if fishID <= 0 {
 		return nil, apperr.ErrInvalidFishID
 	}
@@ -32,6 +48,16 @@ func (uc *FishUsecase) SpawnFish(ctx context.Context, roomID string, fishID int,
 		return nil, apperr.ErrInvalidFishUID
 	}
 
+	var instance *entity.FishInstance
+	err = retryRoomConflict(ctx, func() (err error) {
+		instance, err = uc.spawnFish(ctx, roomID, fishID, fishUID, pathID)
+		return err
+	})
+	return instance, err
+}
+
+// spawnFish is one attempt of SpawnFish.
+func (uc *FishUsecase) spawnFish(ctx context.Context, roomID string, fishID int, fishUID string, pathID int) (*entity.FishInstance, error) {
 	room, err := uc.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
 		if errors.Is(err, apperr.ErrNotFound) {
@@ -47,28 +73,113 @@ func (uc *FishUsecase) SpawnFish(ctx context.Context, roomID string, fishID int,
 		return nil, apperr.ErrFishUIDExists
 	}
 
-	fishType, err := uc.fishRepo.GetTypeByID(ctx, fishID)
+	hp, err := uc.spawnHP(ctx, room, fishID)
 	if err != nil {
-		if errors.Is(err, apperr.ErrNotFound) {
-			return nil, apperr.ErrFishTypeNotFound
-		}
 		return nil, err
 	}
 
 	instance := &entity.FishInstance{
 		FishUID:   fishUID,
 		FishID:    fishID,
-		HP:        fishType.BaseHP,
+		HP:        hp,
 		SpawnTime: uc.now().Unix(),
 		PathID:    pathID,
 		Alive:     true,
 	}
 
 	room.FishMap[fishUID] = instance
+	room.NextSeq()
 
 	if err := uc.roomRepo.Save(ctx, room); err != nil {
 		return nil, err
 	}
 
+	events := newEventBatch(room, uc.now())
+	snapshot := *instance
+	events.add(&entity.GameEvent{Type: entity.EventFishSpawned, FishUID: fishUID, Fish: &snapshot})
+	events.flush(ctx, uc.events, uc.publisher)
+
+	logger.FromContext(ctx).Debug("Fish spawned",
+		zap.String("fish_uid", fishUID),
+		zap.Int("fish_id", fishID),
+		zap.Int("path_id", pathID),
+	)
 	return instance, nil
 }
+
+// spawnHP is the health a fish of fishID starts with: in game rooms, the
+// fish type config version the room is pinned to; in other rooms, the fish
+// type catalogue.
+func (uc *FishUsecase) spawnHP(ctx context.Context, room *entity.Room, fishID int) (int, error) {
+	if room.Config.GameName != "" {
+		rules, err := uc.configs.rules(ctx, room)
+		if err != nil {
+			return 0, err
+		}
+		fishType, err := rules.fishType(fishID)
+		if err != nil {
+			return 0, err
+		}
+		return fishType.HP, nil
+	}
+
+	fishType, err := uc.fishRepo.GetTypeByID(ctx, fishID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return 0, apperr.ErrFishTypeNotFound
+		}
+		return 0, err
+	}
+	return fishType.BaseHP, nil
+}
+
+// EscapeFish removes a fish that swam off the end of its path without being
+// killed.
+func (uc *FishUsecase) EscapeFish(ctx context.Context, roomID, fishUID string) (err error) {
+	ctx = logContext(ctx, roomID, "")
+	defer func() {
+		if err != nil {
+			logFailure(ctx, "Fish escape rejected", err, zap.String("fish_uid", fishUID))
+		}
+	}()
+
+	if fishUID == "" {
+		return apperr.ErrInvalidFishUID
+	}
+	return retryRoomConflict(ctx, func() error {
+		return uc.escapeFish(ctx, roomID, fishUID)
+	})
+}
+
+// escapeFish is one attempt of EscapeFish.
+func (uc *FishUsecase) escapeFish(ctx context.Context, roomID, fishUID string) error {
+	room, err := uc.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return apperr.ErrRoomNotFound
+		}
+		return err
+	}
+
+	fish, exists := room.FishMap[fishUID]
+	if !exists {
+		return apperr.ErrFishNotFound
+	}
+	if fish.IsDead() {
+		return apperr.ErrFishAlreadyDead
+	}
+
+	delete(room.FishMap, fishUID)
+	room.NextSeq()
+
+	if err := uc.roomRepo.Save(ctx, room); err != nil {
+		return err
+	}
+
+	events := newEventBatch(room, uc.now())
+	events.add(&entity.GameEvent{Type: entity.EventFishEscaped, FishUID: fishUID})
+	events.flush(ctx, uc.events, uc.publisher)
+
+	logger.FromContext(ctx).Debug("Fish escaped", zap.String("fish_uid", fishUID))
+	return nil
+}
@@ -0,0 +1,462 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/sevices"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
+)
+
+// PutDocument writes body, a complete document in the stored JSON shape, as
+// a new version of a game config document and rolls it out to percent of new
+// rooms (100 makes it the stable version immediately). If body carries a
+// non-zero version it must match the latest version.
+func (uc *GameConfigUsecase) PutDocument(ctx context.Context, kind, gameName, author string, percent int, body []byte) (*gameBaseModels.ConfigVersion, error) {
+	latest, err := uc.latestDocument(ctx, kind, gameName)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, _ := gameBaseModels.NewConfigDocument(kind)
+	if err := decodeStrict(body, doc); err != nil {
+		return nil, err
+	}
+	return uc.saveVersion(ctx, doc, latest, gameName, author, percent)
+}
+
+// PatchDocument applies body as a JSON merge patch (RFC 7386) to the latest
+// version of a document and writes the result as a new version, like
+// PutDocument. Objects are merged, null removes a field and arrays are
+// replaced whole.
+func (uc *GameConfigUsecase) PatchDocument(ctx context.Context, kind, gameName, author string, percent int, body []byte) (*gameBaseModels.ConfigVersion, error) {
+	latest, err := uc.latestDocument(ctx, kind, gameName)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, configNotFoundErr(kind)
+	}
+
+	var patch interface{}
+	if err := decodeJSON(body, &patch); err != nil {
+		return nil, err
+	}
+	latestJSON, err := json.Marshal(latest)
+	if err != nil {
+		return nil, err
+	}
+	var target map[string]interface{}
+	if err := decodeJSON(latestJSON, &target); err != nil {
+		return nil, err
+	}
+	// _id is never written back, and author and created_at describe the new
+	// write, so none of them carry over from the base version.
+	delete(target, "_id")
+	delete(target, "author")
+	delete(target, "created_at")
+	merged, err := json.Marshal(mergePatch(target, patch))
+	if err != nil {
+		return nil, err
+	}
+
+	doc, _ := gameBaseModels.NewConfigDocument(kind)
+	if err := decodeStrict(merged, doc); err != nil {
+		return nil, err
+	}
+	return uc.saveVersion(ctx, doc, latest, gameName, author, percent)
+}
+
+// ListVersions returns up to limit versions of a document, newest first.
+func (uc *GameConfigUsecase) ListVersions(ctx context.Context, kind, gameName string, limit int) ([]*gameBaseModels.ConfigVersion, error) {
+	if _, ok := gameBaseModels.NewConfigDocument(kind); !ok {
+		return nil, apperr.ErrUnknownConfigKind
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return uc.versions.ListVersions(ctx, kind, gameName, limit)
+}
+
+// GetVersion returns one version of a document. Documents written before
+// versioning existed are served from the main collection as their stored
+// version.
+func (uc *GameConfigUsecase) GetVersion(ctx context.Context, kind, gameName string, version int64) (*gameBaseModels.ConfigVersion, error) {
+	if _, ok := gameBaseModels.NewConfigDocument(kind); !ok {
+		return nil, apperr.ErrUnknownConfigKind
+	}
+
+	return loadConfigVersion(ctx, uc.store, uc.versions, kind, gameName, version)
+}
+
+// loadConfigVersion reads one version of a document from the version
+// history, or from the main collection for documents written before
+// versioning existed.
+func loadConfigVersion(ctx context.Context, store port.GameConfigStore, versions port.GameConfigVersionStore, kind, gameName string, version int64) (*gameBaseModels.ConfigVersion, error) {
+	v, err := versions.GetVersion(ctx, kind, gameName, version)
+	if err == nil {
+		return v, nil
+	}
+	if !errors.Is(err, apperr.ErrNotFound) {
+		return nil, err
+	}
+
+	current, err := store.GetDocument(ctx, kind, gameName)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrConfigVersionNotFound
+		}
+		return nil, err
+	}
+	meta := current.Meta()
+	if meta.Version != version {
+		return nil, apperr.ErrConfigVersionNotFound
+	}
+	return &gameBaseModels.ConfigVersion{
+		Kind:      kind,
+		GameName:  gameName,
+		Version:   meta.Version,
+		Author:    meta.Author,
+		CreatedAt: meta.CreatedAt,
+		Document:  current,
+	}, nil
+}
+
+// GetRollout reports which versions new rooms currently receive.
+func (uc *GameConfigUsecase) GetRollout(ctx context.Context, kind, gameName string) (*gameBaseModels.ConfigRollout, error) {
+	if _, ok := gameBaseModels.NewConfigDocument(kind); !ok {
+		return nil, apperr.ErrUnknownConfigKind
+	}
+	return uc.currentRollout(ctx, kind, gameName)
+}
+
+// Promote rolls an existing version out to percent of new rooms. At 100 the
+// version becomes stable: it replaces the document in the main collection and
+// any other candidate is dropped.
+func (uc *GameConfigUsecase) Promote(ctx context.Context, kind, gameName string, version int64, percent int, author string) (*gameBaseModels.ConfigRollout, error) {
+	v, err := uc.GetVersion(ctx, kind, gameName, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.validateWithStable(ctx, v.Document); err != nil {
+		return nil, err
+	}
+	return uc.rollOut(ctx, v, percent, author)
+}
+
+// Rollback makes a previous version stable again for all new rooms. Rooms
+// already running keep the version they pinned at creation.
+func (uc *GameConfigUsecase) Rollback(ctx context.Context, kind, gameName string, version int64, author string) (*gameBaseModels.ConfigRollout, error) {
+	return uc.Promote(ctx, kind, gameName, version, 100, author)
+}
+
+// ValidateGame checks the stable config documents of a game on their own
+// and against each other and returns every violation found.
+func (uc *GameConfigUsecase) ValidateGame(ctx context.Context, gameName string) ([]gameBaseModels.Violation, error) {
+	set, err := uc.loadConfigSet(ctx, gameName)
+	if err != nil {
+		return nil, err
+	}
+	if len(set.Documents()) == 0 {
+		return nil, apperr.ErrNotFound
+	}
+	return gameBaseSevices.ValidateGame(set), nil
+}
+
+// ValidateAll runs ValidateGame for every game with config documents and
+// returns the violations of the games that have any.
+func (uc *GameConfigUsecase) ValidateAll(ctx context.Context) (map[string][]gameBaseModels.Violation, error) {
+	gameNames, err := uc.store.ListGameNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string][]gameBaseModels.Violation{}
+	for _, gameName := range gameNames {
+		violations, err := uc.ValidateGame(ctx, gameName)
+		if err != nil {
+			return nil, fmt.Errorf("validate %s: %w", gameName, err)
+		}
+		if len(violations) > 0 {
+			result[gameName] = violations
+		}
+	}
+	return result, nil
+}
+
+// validateWithStable checks doc together with the stable documents of the
+// other kinds of its game: it has to agree with them, and they with it.
+func (uc *GameConfigUsecase) validateWithStable(ctx context.Context, doc gameBaseModels.ConfigDocument) error {
+	set, err := uc.loadConfigSet(ctx, doc.Meta().GameName)
+	if err != nil {
+		return err
+	}
+	set.Put(doc)
+	if violations := gameBaseSevices.ValidateGame(set); len(violations) > 0 {
+		return &gameBaseModels.ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// loadConfigSet reads the stable document of every kind for a game,
+// leaving out kinds the game has no document for.
+func (uc *GameConfigUsecase) loadConfigSet(ctx context.Context, gameName string) (*gameBaseModels.ConfigSet, error) {
+	set := &gameBaseModels.ConfigSet{GameName: gameName}
+	for _, kind := range gameBaseModels.ConfigKinds {
+		doc, err := uc.store.GetDocument(ctx, kind, gameName)
+		if err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		set.Put(doc)
+	}
+	return set, nil
+}
+
+// latestDocument returns the newest version of a document, falling back to
+// the main collection for documents that predate version history, or nil if
+// the document does not exist yet.
+func (uc *GameConfigUsecase) latestDocument(ctx context.Context, kind, gameName string) (gameBaseModels.ConfigDocument, error) {
+	if gameName == "" {
+		return nil, apperr.New(apperr.CodeInvalidGameConfig, "game_name is required")
+	}
+	if _, ok := gameBaseModels.NewConfigDocument(kind); !ok {
+		return nil, apperr.ErrUnknownConfigKind
+	}
+
+	versions, err := uc.versions.ListVersions(ctx, kind, gameName, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) > 0 {
+		return versions[0].Document, nil
+	}
+
+	current, err := uc.store.GetDocument(ctx, kind, gameName)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return current, nil
+}
+
+func (uc *GameConfigUsecase) saveVersion(ctx context.Context, doc, latest gameBaseModels.ConfigDocument, gameName, author string, percent int) (*gameBaseModels.ConfigVersion, error) {
+	if percent < 0 || percent > 100 {
+		return nil, apperr.ErrInvalidRolloutPercent
+	}
+
+	latestVersion := int64(0)
+	if latest != nil {
+		latestVersion = latest.Meta().Version
+	}
+
+	meta := doc.Meta()
+	if meta.Version != 0 && meta.Version != latestVersion {
+		return nil, apperr.ErrConfigVersionConflict
+	}
+	meta.GameName = gameName
+	meta.Version = latestVersion + 1
+	meta.Author = author
+	meta.CreatedAt = uc.now().Unix()
+
+	if err := uc.validateWithStable(ctx, doc); err != nil {
+		logger.FromContext(ctx).Info("Game config version rejected",
+			zap.String("kind", doc.Kind()),
+			zap.String("game_name", gameName),
+			zap.String("author", author),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	version := &gameBaseModels.ConfigVersion{
+		Kind:      doc.Kind(),
+		GameName:  gameName,
+		Version:   meta.Version,
+		Author:    author,
+		CreatedAt: meta.CreatedAt,
+		Document:  doc,
+	}
+	if err := uc.archiveUnversioned(ctx, latest); err != nil {
+		return nil, err
+	}
+	if err := uc.versions.SaveVersion(ctx, version); err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("Game config version saved",
+		zap.String("kind", version.Kind),
+		zap.String("game_name", gameName),
+		zap.Int64("version", version.Version),
+		zap.String("author", author),
+	)
+	if _, err := uc.rollOut(ctx, version, percent, author); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// archiveUnversioned copies a document written before versioning existed
+// into the version history, so rooms pinned to it can still load it once a
+// new version replaces it in the main collection.
+func (uc *GameConfigUsecase) archiveUnversioned(ctx context.Context, latest gameBaseModels.ConfigDocument) error {
+	if latest == nil {
+		return nil
+	}
+	meta := latest.Meta()
+	_, err := uc.versions.GetVersion(ctx, latest.Kind(), meta.GameName, meta.Version)
+	if err == nil || !errors.Is(err, apperr.ErrNotFound) {
+		return err
+	}
+	err = uc.versions.SaveVersion(ctx, &gameBaseModels.ConfigVersion{
+		Kind:      latest.Kind(),
+		GameName:  meta.GameName,
+		Version:   meta.Version,
+		Author:    meta.Author,
+		CreatedAt: meta.CreatedAt,
+		Document:  latest,
+	})
+	if errors.Is(err, apperr.ErrConfigVersionConflict) {
+		// Archived by a concurrent write.
+		return nil
+	}
+	return err
+}
+
+func (uc *GameConfigUsecase) rollOut(ctx context.Context, v *gameBaseModels.ConfigVersion, percent int, author string) (*gameBaseModels.ConfigRollout, error) {
+	if percent < 0 || percent > 100 {
+		return nil, apperr.ErrInvalidRolloutPercent
+	}
+
+	rollout, err := uc.currentRollout(ctx, v.Kind, v.GameName)
+	if err != nil {
+		return nil, err
+	}
+
+	if percent == 100 {
+		prevVersion := int64(0)
+		current, err := uc.store.GetDocument(ctx, v.Kind, v.GameName)
+		if err == nil {
+			prevVersion = current.Meta().Version
+		} else if !errors.Is(err, apperr.ErrNotFound) {
+			return nil, err
+		}
+		if err := uc.store.SaveDocument(ctx, v.Document, prevVersion); err != nil {
+			return nil, err
+		}
+		rollout.StableVersion = v.Version
+		rollout.CandidateVersion = 0
+		rollout.Percent = 0
+	} else {
+		rollout.CandidateVersion = v.Version
+		rollout.Percent = percent
+	}
+	rollout.UpdatedBy = author
+	rollout.UpdatedAt = uc.now().Unix()
+
+	if err := uc.versions.SaveRollout(ctx, rollout); err != nil {
+		return nil, err
+	}
+	if percent == 100 {
+		if err := uc.cache.Invalidate(ctx, v.Kind, v.GameName); err != nil {
+			return nil, fmt.Errorf("%s config version %d promoted but cache invalidation failed: %w", v.Kind, v.Version, err)
+		}
+	}
+	logger.FromContext(ctx).Info("Game config rolled out",
+		zap.String("kind", v.Kind),
+		zap.String("game_name", v.GameName),
+		zap.Int64("version", v.Version),
+		zap.Int("percent", percent),
+		zap.Int64("stable_version", rollout.StableVersion),
+		zap.String("author", author),
+	)
+	return rollout, nil
+}
+
+// currentRollout returns the stored rollout or, for documents that have
+// never been rolled out, one whose stable version is the main document's.
+func (uc *GameConfigUsecase) currentRollout(ctx context.Context, kind, gameName string) (*gameBaseModels.ConfigRollout, error) {
+	rollout, err := uc.versions.GetRollout(ctx, kind, gameName)
+	if err == nil {
+		return rollout, nil
+	}
+	if !errors.Is(err, apperr.ErrNotFound) {
+		return nil, err
+	}
+
+	rollout = &gameBaseModels.ConfigRollout{Kind: kind, GameName: gameName}
+	current, err := uc.store.GetDocument(ctx, kind, gameName)
+	if err == nil {
+		rollout.StableVersion = current.Meta().Version
+	} else if !errors.Is(err, apperr.ErrNotFound) {
+		return nil, err
+	}
+	return rollout, nil
+}
+
+// decodeStrict rejects unknown fields so a misspelt key fails loudly instead
+// of silently resetting the real field to its zero value.
+func decodeStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return apperr.New(apperr.CodeInvalidGameConfig, "invalid game config document: "+err.Error())
+	}
+	return nil
+}
+
+func decodeJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return apperr.New(apperr.CodeInvalidGameConfig, "invalid game config document: "+err.Error())
+	}
+	return nil
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
+
+func configNotFoundErr(kind string) error {
+	switch kind {
+	case gameBaseModels.ConfigKindBullets:
+		return apperr.ErrBulletConfigNotFound
+	case gameBaseModels.ConfigKindConfigs:
+		return apperr.ErrGameConfigNotFound
+	case gameBaseModels.ConfigKindFeatures:
+		return apperr.ErrGameFeaturesNotFound
+	case gameBaseModels.ConfigKindPaths:
+		return apperr.ErrGamePathsNotFound
+	case gameBaseModels.ConfigKindRTPs:
+		return apperr.ErrGameRTPNotFound
+	case gameBaseModels.ConfigKindFishTypes:
+		return apperr.ErrGameFishTypesNotFound
+	}
+	return apperr.ErrNotFound
+}
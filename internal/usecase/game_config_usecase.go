@@ -2,18 +2,31 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
 )
 
 type GameConfigUsecase struct {
 	gameConfigRepo port.GameConfigRepository
+	store          port.GameConfigStore
+	versions       port.GameConfigVersionStore
+	cache          port.GameConfigCache
+	now            func() time.Time
 }
 
-func NewGameConfigUsecase(gameConfigRepo port.GameConfigRepository) *GameConfigUsecase {
+func NewGameConfigUsecase(gameConfigRepo port.GameConfigRepository, store port.GameConfigStore, versions port.GameConfigVersionStore, cache port.GameConfigCache, opts ...Option) *GameConfigUsecase {
+	o := newOptions(opts)
 	return &GameConfigUsecase{
 		gameConfigRepo: gameConfigRepo,
+		store:          store,
+		versions:       versions,
+		cache:          cache,
+		now:            o.now,
 	}
 }
 
@@ -46,3 +59,54 @@ func (uc *GameConfigUsecase) GetGameRTP(ctx context.Context, gameName string) (*
 func (uc *GameConfigUsecase) GetGameFishTypes(ctx context.Context, gameName string) (*gameBaseModels.GameFishTypes, error) {
 	return uc.gameConfigRepo.GetGameFishTypes(ctx, gameName)
 }
+
+// WarmUp loads every stored config document of every game through the
+// cache so the first requests after startup do not all miss. It returns the
+// number of documents loaded; failures for individual games are joined into
+// the error and do not stop the rest.
+func (uc *GameConfigUsecase) WarmUp(ctx context.Context) (int, error) {
+	gameNames, err := uc.store.ListGameNames(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	loaded := 0
+	var errs []error
+	for _, gameName := range gameNames {
+		set, err := uc.loadConfigSet(ctx, gameName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("warm up %s: %w", gameName, err))
+			continue
+		}
+		for _, doc := range set.Documents() {
+			if err := uc.loadCached(ctx, doc.Kind(), gameName); err != nil {
+				errs = append(errs, fmt.Errorf("warm up %s %s: %w", gameName, doc.Kind(), err))
+				continue
+			}
+			loaded++
+		}
+	}
+	return loaded, errors.Join(errs...)
+}
+
+// loadCached reads one document through gameConfigRepo, discarding it.
+func (uc *GameConfigUsecase) loadCached(ctx context.Context, kind, gameName string) error {
+	var err error
+	switch kind {
+	case gameBaseModels.ConfigKindBullets:
+		_, err = uc.gameConfigRepo.GetBulletConfig(ctx, gameName)
+	case gameBaseModels.ConfigKindConfigs:
+		_, err = uc.gameConfigRepo.GetGameConfig(ctx, gameName)
+	case gameBaseModels.ConfigKindFeatures:
+		_, err = uc.gameConfigRepo.GetGameFeatures(ctx, gameName)
+	case gameBaseModels.ConfigKindPaths:
+		_, err = uc.gameConfigRepo.GetGamePaths(ctx, gameName)
+	case gameBaseModels.ConfigKindRTPs:
+		_, err = uc.gameConfigRepo.GetGameRTP(ctx, gameName)
+	case gameBaseModels.ConfigKindFishTypes:
+		_, err = uc.gameConfigRepo.GetGameFishTypes(ctx, gameName)
+	default:
+		err = apperr.ErrUnknownConfigKind
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/sevices"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+// gameRules is the part of a game's config that prices and resolves shots
+// in its rooms: what each bullet costs and hits for, the RTP each fish and
+// bullet pays back, and each fish's health and reward.
+type gameRules struct {
+	bullets   *gameBaseModels.BulletConfig
+	rtp       *gameBaseModels.GameRTP
+	fishTypes *gameBaseModels.GameFishTypes
+}
+
+// pinnedConfigs loads game config documents at the versions rooms were
+// pinned to when they were created. A version never changes once written,
+// so every document loaded is kept for the life of the process.
+type pinnedConfigs struct {
+	store    port.GameConfigStore
+	versions port.GameConfigVersionStore
+	docs     sync.Map // pinnedKey -> gameBaseModels.ConfigDocument
+}
+
+type pinnedKey struct {
+	kind     string
+	gameName string
+	version  int64
+}
+
+func newPinnedConfigs(store port.GameConfigStore, versions port.GameConfigVersionStore) *pinnedConfigs {
+	return &pinnedConfigs{store: store, versions: versions}
+}
+
+// rules loads the rules a game room plays by. A room pinned to no version
+// of one of the three documents cannot be played.
+func (p *pinnedConfigs) rules(ctx context.Context, room *entity.Room) (*gameRules, error) {
+	bullets, err := p.document(ctx, room, gameBaseModels.ConfigKindBullets)
+	if err != nil {
+		return nil, err
+	}
+	rtp, err := p.document(ctx, room, gameBaseModels.ConfigKindRTPs)
+	if err != nil {
+		return nil, err
+	}
+	fishTypes, err := p.document(ctx, room, gameBaseModels.ConfigKindFishTypes)
+	if err != nil {
+		return nil, err
+	}
+	return &gameRules{
+		bullets:   bullets.(*gameBaseModels.BulletConfig),
+		rtp:       rtp.(*gameBaseModels.GameRTP),
+		fishTypes: fishTypes.(*gameBaseModels.GameFishTypes),
+	}, nil
+}
+
+func (p *pinnedConfigs) document(ctx context.Context, room *entity.Room, kind string) (gameBaseModels.ConfigDocument, error) {
+	version, ok := room.Config.ConfigVersions[kind]
+	if !ok {
+		return nil, configNotFoundErr(kind)
+	}
+	key := pinnedKey{kind: kind, gameName: room.Config.GameName, version: version}
+	if doc, ok := p.docs.Load(key); ok {
+		return doc.(gameBaseModels.ConfigDocument), nil
+	}
+
+	v, err := loadConfigVersion(ctx, p.store, p.versions, kind, room.Config.GameName, version)
+	if err != nil {
+		return nil, err
+	}
+	p.docs.Store(key, v.Document)
+	return v.Document, nil
+}
+
+// bullet returns the bullet fired by a gun. Guns and bullets share ids.
+func (r *gameRules) bullet(gunID int) (*gameBaseModels.BulletInfo, error) {
+	for i := range r.bullets.Data.Bullets {
+		if b := &r.bullets.Data.Bullets[i]; b.BulletID == gunID {
+			return b, nil
+		}
+	}
+	return nil, apperr.ErrGunNotFound
+}
+
+func (r *gameRules) fishType(fishID int) (*gameBaseModels.FishType, error) {
+	for i := range r.fishTypes.Data.FishTypes {
+		if f := &r.fishTypes.Data.FishTypes[i]; f.FishID == fishID {
+			return f, nil
+		}
+	}
+	return nil, apperr.ErrFishTypeNotFound
+}
+
+// hitInput describes bullet reaching a fish of fishID with hp left.
+func (r *gameRules) hitInput(bullet *gameBaseModels.BulletInfo, fishID, hp int) (gameBaseModels.HitInput, error) {
+	fishType, err := r.fishType(fishID)
+	if err != nil {
+		return gameBaseModels.HitInput{}, err
+	}
+	return gameBaseSevices.HitInputFor(&r.rtp.Data, fishType, bullet, hp), nil
+}
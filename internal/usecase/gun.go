@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
+)
+
+// ChangeGun switches the gun a seated player fires with. Game rooms only
+// offer the bullets of the config version they are pinned to. Bullets
+// already in flight keep the cost and damage of the gun they were fired
+// from.
+func (uc *ShootUsecase) ChangeGun(ctx context.Context, roomID, playerID string, gunID int) (_ *entity.Player, err error) {
+	ctx, span := startSpan(ctx, "ShootUsecase.ChangeGun", roomAttr(roomID), playerAttr(playerID))
+	defer endSpan(span, &err)
+	ctx = logContext(ctx, roomID, playerID)
+	defer func() {
+		if err != nil {
+			logFailure(ctx, "Gun change rejected", err, zap.Int("gun_id", gunID))
+		}
+	}()
+
+	if roomID == "" {
+		return nil, apperr.ErrInvalidRoomID
+	}
+	if playerID == "" {
+		return nil, apperr.ErrInvalidPlayerID
+	}
+
+	var player *entity.Player
+	err = retryRoomConflict(ctx, func() (err error) {
+		player, err = uc.changeGun(ctx, roomID, playerID, gunID)
+		return err
+	})
+	return player, err
+}
+
+// changeGun is one attempt of ChangeGun.
+func (uc *ShootUsecase) changeGun(ctx context.Context, roomID, playerID string, gunID int) (*entity.Player, error) {
+	room, err := uc.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrRoomNotFound
+		}
+		return nil, err
+	}
+	player, ok := room.Players[playerID]
+	if !ok || player.RoomID != roomID {
+		return nil, apperr.ErrPlayerNotInRoom
+	}
+
+	gun, err := uc.gunFor(ctx, room, gunID)
+	if err != nil {
+		return nil, err
+	}
+	if player.GunID == gun.GunID {
+		return player, nil
+	}
+
+	previous := player.GunID
+	player.GunID = gun.GunID
+	player.LastActionAt = uc.now().Unix()
+	room.NextSeq()
+
+	// The player record goes first, as on join, so a failed room save
+	// leaves nothing but the record to put back.
+	if err := uc.playerRepo.Save(ctx, player); err != nil {
+		return nil, err
+	}
+	if err := uc.roomRepo.Save(ctx, room); err != nil {
+		player.GunID = previous
+		_ = uc.playerRepo.Save(ctx, player)
+		return nil, err
+	}
+
+	events := newEventBatch(room, uc.now())
+	events.add(&entity.GameEvent{Type: entity.EventGunChanged, PlayerID: playerID, GunID: gun.GunID})
+	events.flush(ctx, uc.events, uc.publisher)
+
+	logger.FromContext(ctx).Debug("Gun changed", zap.Int("gun_id", gun.GunID), zap.Int("bullet_cost", gun.BulletCost))
+	return player, nil
+}
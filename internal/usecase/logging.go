@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// logContext returns ctx whose logger also writes the room and player a
+// call is about. Empty ids are left out.
+func logContext(ctx context.Context, roomID, playerID string) context.Context {
+	fields := make([]zap.Field, 0, 2)
+	if roomID != "" {
+		fields = append(fields, zap.String("room_id", roomID))
+	}
+	if playerID != "" {
+		fields = append(fields, zap.String("player_id", playerID))
+	}
+	return logger.With(ctx, fields...)
+}
+
+// failureLevel is the level a failed call is logged at. Domain errors are
+// the rules working, such as a shot without balance or a full room, so they
+// are info; anything else is an error.
+func failureLevel(err error) zapcore.Level {
+	if apperr.CodeOf(err) != "" {
+		return zapcore.InfoLevel
+	}
+	return zapcore.ErrorLevel
+}
+
+// logFailure logs a failed call with the code of a domain error, or the
+// error itself when it is unexpected.
+func logFailure(ctx context.Context, msg string, err error, fields ...zap.Field) {
+	lvl := failureLevel(err)
+	if lvl == zapcore.InfoLevel {
+		fields = append(fields, zap.String("code", string(apperr.CodeOf(err))))
+	} else {
+		fields = append(fields, zap.Error(err))
+	}
+	logger.FromContext(ctx).Log(lvl, msg, fields...)
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"io"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+)
+
+// Option replaces a dependency the usecases otherwise take from the
+// process: the wall clock, sleeping and the entropy behind fair-session
+// seeds. Tests use them to make runs repeatable. WithPublisher,
+// WithReleasers and WithMetrics are the ones production passes, to push
+// room events to websocket clients, to free per-room state when a room
+// closes and to count gameplay for /metrics. Every constructor accepts
+// every option and ignores those it has no use for. Hit rolls come from the
+// port.RNG given to NewShootUsecase.
+type Option func(*options)
+
+type options struct {
+	now       func() time.Time
+	sleep     func(time.Duration)
+	entropy   io.Reader
+	publisher port.RoomPublisher
+	releasers []port.RoomReleaser
+	metrics   port.GameplayMetrics
+}
+
+// WithClock makes the usecase read the time from now.
+func WithClock(now func() time.Time) Option {
+	return func(o *options) { o.now = now }
+}
+
+// WithSleep replaces the waits between wallet retries.
+func WithSleep(sleep func(time.Duration)) Option {
+	return func(o *options) { o.sleep = sleep }
+}
+
+// WithEntropy makes fair-session server and client seeds read from r.
+func WithEntropy(r io.Reader) Option {
+	return func(o *options) { o.entropy = r }
+}
+
+// WithPublisher pushes the events of every saved room mutation to p.
+func WithPublisher(p port.RoomPublisher) Option {
+	return func(o *options) { o.publisher = p }
+}
+
+// WithReleasers has every closing room released from each of releasers.
+func WithReleasers(releasers ...port.RoomReleaser) Option {
+	return func(o *options) { o.releasers = append(o.releasers, releasers...) }
+}
+
+// WithMetrics counts every saved shot into m.
+func WithMetrics(m port.GameplayMetrics) Option {
+	return func(o *options) { o.metrics = m }
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		now:       time.Now,
+		sleep:     time.Sleep,
+		entropy:   rand.Reader,
+		publisher: nopPublisher{},
+		metrics:   nopMetrics{},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+type nopPublisher struct{}
+
+func (nopPublisher) Publish(string, int64, []*entity.GameEvent) {}
+
+type nopMetrics struct{}
+
+func (nopMetrics) ShotFired(string, int, int64)           {}
+func (nopMetrics) ShotResolved(string, bool, bool, int64) {}
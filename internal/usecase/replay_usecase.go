@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type ReplayUsecase struct {
+	events port.EventStore
+}
+
+func NewReplayUsecase(events port.EventStore) *ReplayUsecase {
+	return &ReplayUsecase{
+		events: events,
+	}
+}
+
+// Replay rebuilds a room and its players' balances from the event log as of
+// event sequence atSeq. An atSeq of zero or less replays the whole log.
+func (uc *ReplayUsecase) Replay(ctx context.Context, roomID string, atSeq int64) (*entity.RoomReplay, error) {
+	if roomID == "" {
+		return nil, apperr.ErrInvalidRoomID
+	}
+
+	events, err := uc.events.List(ctx, roomID, 1, atSeq)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, apperr.ErrRoomNotFound
+	}
+
+	replay := entity.NewRoomReplay(roomID)
+	for _, e := range events {
+		replay.Apply(e)
+	}
+	return replay, nil
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
+)
+
+// roomSaveAttempts bounds how often a room mutation is redone after another
+// request saved the same room between its load and its save.
+const roomSaveAttempts = 5
+
+// retryRoomConflict runs mutate, which loads a room, changes it and saves
+// it, again for as long as the save reports apperr.ErrRoomConflict, up to
+// roomSaveAttempts times. mutate must leave nothing behind when its room
+// save conflicts, so that the next attempt starts from the stored room.
+func retryRoomConflict(ctx context.Context, mutate func() error) error {
+	var err error
+	for attempt := 1; attempt <= roomSaveAttempts; attempt++ {
+		err = mutate()
+		if !errors.Is(err, apperr.ErrRoomConflict) || ctx.Err() != nil {
+			return err
+		}
+		logger.FromContext(ctx).Debug("Room saved by another request; retrying", zap.Int("attempt", attempt))
+	}
+	return err
+}
@@ -3,28 +3,70 @@ package usecase
 import (
 	"context"
 	"errors"
+	"io"
+	"sync/atomic"
 	"time"
 
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/sevices"
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
 	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
 )
 
 type RoomUsecase struct {
-	roomRepo   port.RoomRepository
-	playerRepo port.PlayerRepository
-	now        func() time.Time
+	roomRepo        port.RoomRepository
+	playerRepo      port.PlayerRepository
+	wallet          port.WalletProvider
+	transferRepo    port.WalletTransferRepository
+	fairSessionRepo port.FairSessionRepository
+	events          port.EventStore
+	rtpRepo         port.RTPRepository
+	configStore     port.GameConfigStore
+	configVersions  port.GameConfigVersionStore
+	joinsStopped    atomic.Bool
+	publisher       port.RoomPublisher
+	releasers       []port.RoomReleaser
+	now             func() time.Time
+	sleep           func(time.Duration)
+	entropy         io.Reader
 }
 
-func NewRoomUsecase(roomRepo port.RoomRepository, playerRepo port.PlayerRepository) *RoomUsecase {
+func NewRoomUsecase(roomRepo port.RoomRepository, playerRepo port.PlayerRepository, wallet port.WalletProvider, transferRepo port.WalletTransferRepository, fairSessionRepo port.FairSessionRepository, events port.EventStore, rtpRepo port.RTPRepository, configStore port.GameConfigStore, configVersions port.GameConfigVersionStore, opts ...Option) *RoomUsecase {
+	o := newOptions(opts)
 	return &RoomUsecase{
-		roomRepo:   roomRepo,
-		playerRepo: playerRepo,
-		now:        time.Now,
+		roomRepo:        roomRepo,
+		playerRepo:      playerRepo,
+		wallet:          wallet,
+		transferRepo:    transferRepo,
+		fairSessionRepo: fairSessionRepo,
+		events:          events,
+		rtpRepo:         rtpRepo,
+		configStore:     configStore,
+		configVersions:  configVersions,
+		publisher:       o.publisher,
+		releasers:       o.releasers,
+		now:             o.now,
+		sleep:           o.sleep,
+		entropy:         o.entropy,
 	}
 }
 
-func (uc *RoomUsecase) CreateRoom(ctx context.Context, roomID string, maxPlayers int) (*entity.Room, error) {
+// CreateRoom opens a room. When gameName is set the room is pinned to the
+// game config versions its rollout bucket receives, so later config changes
+// do not alter a running room.
+func (uc *RoomUsecase) CreateRoom(ctx context.Context, roomID, gameName string, maxPlayers int, provablyFair bool) (_ *entity.Room, err error) {
+	ctx, span := startSpan(ctx, "RoomUsecase.CreateRoom", roomAttr(roomID), gameAttr(gameName))
+	defer endSpan(span, &err)
+	ctx = logContext(ctx, roomID, "")
+	defer func() {
+		if err != nil {
+			logFailure(ctx, "Room not created", err, zap.String("game_name", gameName))
+		}
+	}()
+
 	if maxPlayers <= 0 {
 		return nil, apperr.ErrInvalidMaxPlayers
 	}
@@ -40,31 +82,89 @@ func (uc *RoomUsecase) CreateRoom(ctx context.Context, roomID string, maxPlayers
 		return nil, err
 	}
 
+	var configVersions map[string]int64
+	if gameName != "" {
+		configVersions, err = uc.pinConfigVersions(ctx, roomID, gameName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	room := &entity.Room{
 		RoomID:  roomID,
 		Status:  "open",
 		Players: map[string]*entity.Player{},
 		FishMap: map[string]*entity.FishInstance{},
+		Bullets: map[string]*entity.Bullet{},
 		Config: entity.RoomConfig{
-			MaxPlayers: maxPlayers,
+			MaxPlayers:          maxPlayers,
+			BulletTTLMs:         entity.DefaultBulletTTLMs,
+			MaxLiveBullets:      entity.DefaultMaxLiveBullets,
+			ExpiredBulletPolicy: entity.ExpiredBulletRefund,
+			ProvablyFair:        provablyFair,
+			GameName:            gameName,
+			ConfigVersions:      configVersions,
 		},
 	}
+	room.NextSeq()
 
 	if err := uc.roomRepo.Save(ctx, room); err != nil {
+		if errors.Is(err, apperr.ErrRoomConflict) {
+			return nil, apperr.ErrRoomAlreadyExists
+		}
 		return nil, err
 	}
 
+	events := newEventBatch(room, uc.now())
+	config := room.Config
+	events.add(&entity.GameEvent{Type: entity.EventRoomCreated, Config: &config})
+	events.flush(ctx, uc.events, uc.publisher)
+
+	logger.FromContext(ctx).Info("Room created",
+		zap.String("game_name", gameName),
+		zap.Int("max_players", maxPlayers),
+		zap.Bool("provably_fair", provablyFair),
+		zap.Any("config_versions", configVersions),
+	)
 	return room, nil
 }
 
-func (uc *RoomUsecase) JoinRoom(ctx context.Context, roomID, playerID string, seatID int, initialBalance int64) (*entity.Room, *entity.Player, error) {
+// JoinRoom seats a player and moves buyIn from the operator wallet into the
+// player's game balance. The debit is rolled back if the seat cannot be
+// persisted. In provably-fair rooms it also commits to a new server seed;
+// clientSeed is the player's seed for that session, or random when empty.
+func (uc *RoomUsecase) JoinRoom(ctx context.Context, roomID, playerID string, seatID int, buyIn int64, clientSeed string) (_ *entity.Room, _ *entity.Player, err error) {
+	ctx, span := startSpan(ctx, "RoomUsecase.JoinRoom", roomAttr(roomID), playerAttr(playerID))
+	defer endSpan(span, &err)
+	ctx = logContext(ctx, roomID, playerID)
+	defer func() {
+		if err != nil {
+			logFailure(ctx, "Join rejected", err, zap.Int("seat_id", seatID), zap.Int64("buy_in", buyIn))
+		}
+	}()
+
+	if uc.joinsStopped.Load() {
+		return nil, nil, apperr.ErrShuttingDown
+	}
 	if seatID < 0 {
 		return nil, nil, apperr.ErrInvalidSeat
 	}
-	if initialBalance < 0 {
-		return nil, nil, apperr.ErrInvalidBalance
+	if buyIn < 0 {
+		return nil, nil, apperr.ErrInvalidBuyIn
 	}
 
+	var room *entity.Room
+	var player *entity.Player
+	err = retryRoomConflict(ctx, func() (err error) {
+		room, player, err = uc.joinRoom(ctx, roomID, playerID, seatID, buyIn, clientSeed)
+		return err
+	})
+	return room, player, err
+}
+
+// joinRoom is one attempt of JoinRoom. A conflicting room save undoes the
+// player record and refunds the buy-in like any other failed room save.
+func (uc *RoomUsecase) joinRoom(ctx context.Context, roomID, playerID string, seatID int, buyIn int64, clientSeed string) (*entity.Room, *entity.Player, error) {
 	room, err := uc.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
 		if errors.Is(err, apperr.ErrNotFound) {
@@ -94,7 +194,6 @@ func (uc *RoomUsecase) JoinRoom(ctx context.Context, roomID, playerID string, se
 		}
 		player = &entity.Player{
 			PlayerID: playerID,
-			Balance:  initialBalance,
 		}
 	}
 
@@ -108,6 +207,30 @@ func (uc *RoomUsecase) JoinRoom(ctx context.Context, roomID, playerID string, se
 	if _, exists := room.Players[playerID]; exists {
 		return nil, nil, apperr.ErrPlayerAlreadyIn
 	}
+	if room.Config.ProvablyFair && len(clientSeed) > maxClientSeedLength {
+		return nil, nil, apperr.ErrInvalidClientSeed
+	}
+	previous := *player
+	previous.AppliedWalletTxs = append([]string(nil), player.AppliedWalletTxs...)
+
+	var transfer *entity.WalletTransfer
+	if buyIn > 0 {
+		transfer, err = uc.buyIn(ctx, roomID, player, buyIn)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if room.Config.ProvablyFair {
+		if _, err := uc.startFairSession(ctx, roomID, playerID, clientSeed, player.FairNonce); err != nil {
+			uc.rollbackBuyIn(ctx, transfer)
+			return nil, nil, err
+		}
+		// The last stay's nonces are recorded on its revealed session; the
+		// new seat counts from zero, also if this join is undone.
+		player.FairNonce = 0
+		previous.FairNonce = 0
+	}
 
 	player.SeatID = seatID
 	player.RoomID = roomID
@@ -115,18 +238,83 @@ func (uc *RoomUsecase) JoinRoom(ctx context.Context, roomID, playerID string, se
 	player.LastActionAt = uc.now().Unix()
 
 	room.Players[playerID] = player
+	room.NextSeq()
 
-	if err := uc.roomRepo.Save(ctx, room); err != nil {
+	// The player record goes first: it carries the credit reconciliation
+	// looks for, so the seat is never persisted with chips the wallet was
+	// refunded for.
+	if err := uc.playerRepo.Save(ctx, player); err != nil {
+		uc.rollbackBuyIn(ctx, transfer)
 		return nil, nil, err
 	}
-	if err := uc.playerRepo.Save(ctx, player); err != nil {
+	if err := uc.roomRepo.Save(ctx, room); err != nil {
+		uc.undoJoin(ctx, &previous, transfer)
 		return nil, nil, err
 	}
 
+	if transfer != nil {
+		uc.finishTransfer(ctx, transfer, entity.WalletTransferCommitted)
+	}
+
+	events := newEventBatch(room, uc.now())
+	events.balance(player, buyIn, entity.BalanceReasonBuyIn)
+	events.add(&entity.GameEvent{
+		Type:     entity.EventPlayerJoined,
+		PlayerID: playerID,
+		SeatID:   seatID,
+		Balance:  player.Balance,
+	})
+	events.flush(ctx, uc.events, uc.publisher)
+
+	logger.FromContext(ctx).Info("Player joined room",
+		zap.Int("seat_id", seatID),
+		zap.Int64("buy_in", buyIn),
+		zap.Int64("balance", player.Balance),
+	)
 	return room, player, nil
 }
 
-func (uc *RoomUsecase) LeaveRoom(ctx context.Context, roomID, playerID string) (*entity.Room, *entity.Player, error) {
+// undoJoin restores the player record of a join whose room write failed.
+// The buy-in is refunded only once the record no longer holds it; if the
+// restore fails too, the transfer stays pending and reconciliation commits
+// it, since the record still shows the credit applied.
+func (uc *RoomUsecase) undoJoin(ctx context.Context, previous *entity.Player, transfer *entity.WalletTransfer) {
+	if err := uc.playerRepo.Save(ctx, previous); err != nil {
+		if transfer != nil {
+			uc.leavePending(ctx, transfer, err)
+		}
+		return
+	}
+	uc.rollbackBuyIn(ctx, transfer)
+}
+
+// StopJoins makes every later JoinRoom fail with ErrShuttingDown, so no
+// player is seated on an instance that is about to stop. Leaving still works.
+func (uc *RoomUsecase) StopJoins() {
+	uc.joinsStopped.Store(true)
+}
+
+func (uc *RoomUsecase) LeaveRoom(ctx context.Context, roomID, playerID string) (_ *entity.Room, _ *entity.Player, err error) {
+	ctx, span := startSpan(ctx, "RoomUsecase.LeaveRoom", roomAttr(roomID), playerAttr(playerID))
+	defer endSpan(span, &err)
+	ctx = logContext(ctx, roomID, playerID)
+	defer func() {
+		if err != nil {
+			logFailure(ctx, "Leave rejected", err)
+		}
+	}()
+
+	var room *entity.Room
+	var player *entity.Player
+	err = retryRoomConflict(ctx, func() (err error) {
+		room, player, err = uc.leaveRoom(ctx, roomID, playerID)
+		return err
+	})
+	return room, player, err
+}
+
+// leaveRoom is one attempt of LeaveRoom.
+func (uc *RoomUsecase) leaveRoom(ctx context.Context, roomID, playerID string) (*entity.Room, *entity.Player, error) {
 	room, err := uc.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
 		if errors.Is(err, apperr.ErrNotFound) {
@@ -140,19 +328,100 @@ func (uc *RoomUsecase) LeaveRoom(ctx context.Context, roomID, playerID string) (
 		return nil, nil, apperr.ErrPlayerNotInRoom
 	}
 
+	// Settle bullets still in flight before the balance is cashed out.
+	events := newEventBatch(room, uc.now())
+	dropped := room.DropBullets(playerID)
+	events.settled(dropped)
 	delete(room.Players, playerID)
 
+	// Record the cash-out before zeroing the balance so a crash between the
+	// two leaves a pending transfer for reconciliation rather than lost funds.
+	var transfer *entity.WalletTransfer
+	if player.Balance > 0 {
+		transfer = uc.newTransfer(entity.WalletTransferCashOut, roomID, playerID, player.Balance)
+		if err := uc.transferRepo.Save(ctx, transfer); err != nil {
+			return nil, nil, err
+		}
+		player.Balance = 0
+		player.MarkWalletTxApplied(transfer.TxID)
+		events.balance(player, -transfer.Amount, entity.BalanceReasonCashOut)
+	}
+	events.add(&entity.GameEvent{Type: entity.EventPlayerLeft, PlayerID: playerID})
+
 	player.RoomID = ""
 	player.IsOnline = false
 	player.SeatID = 0
 	player.LastActionAt = uc.now().Unix()
+	room.NextSeq()
 
 	if err := uc.roomRepo.Save(ctx, room); err != nil {
+		// The balance was never zeroed, so the cash-out must not be paid.
+		if transfer != nil && errors.Is(err, apperr.ErrRoomConflict) {
+			uc.finishTransfer(ctx, transfer, entity.WalletTransferFailed)
+		}
 		return nil, nil, err
 	}
+	// Reveal only once the seat is gone: a shot rolled from the room as it
+	// was loaded before this save conflicts with it and finds the player
+	// gone on retry, so no shot is rolled with a seed the player knows. A
+	// failed reveal leaves the session active; the next join reveals it.
+	if room.Config.ProvablyFair {
+		if err := uc.revealFairSession(ctx, roomID, playerID, player.FairNonce); err != nil {
+			logger.FromContext(ctx).Error("Fair session not revealed on leave", zap.Error(err))
+		}
+	}
 	if err := uc.playerRepo.Save(ctx, player); err != nil {
 		return nil, nil, err
 	}
+	events.flush(ctx, uc.events, uc.publisher)
+	// Refunded bullets come off the room's bets, as they do on expiry. The
+	// player has already left, so a failed write does not fail the request.
+	if err := recordRTP(ctx, uc.rtpRepo, roomID, -refundTotal(room, dropped), 0); err != nil {
+		logger.FromContext(ctx).Error("Leave refunds not booked for RTP", zap.Error(err))
+	}
+
+	// The last player out closes the room.
+	if len(room.Players) == 0 {
+		for _, r := range uc.releasers {
+			r.ReleaseRoom(roomID)
+		}
+	}
+
+	cashedOut := int64(0)
+	if transfer != nil {
+		cashedOut = transfer.Amount
+		// A failed credit stays pending and is retried by reconciliation; the
+		// player has already left, so it does not fail the request.
+		_ = uc.cashOut(ctx, transfer)
+	}
 
+	logger.FromContext(ctx).Info("Player left room", zap.Int64("cash_out", cashedOut))
 	return room, player, nil
 }
+
+// pinConfigVersions picks, for each config kind of a game, the version a new
+// room starts with. Kinds the game has no document for are left out.
+func (uc *RoomUsecase) pinConfigVersions(ctx context.Context, roomID, gameName string) (map[string]int64, error) {
+	versions := map[string]int64{}
+	for _, kind := range gameBaseModels.ConfigKinds {
+		rollout, err := uc.configVersions.GetRollout(ctx, kind, gameName)
+		if err == nil {
+			versions[kind] = gameBaseSevices.PinnedVersion(rollout, roomID)
+			continue
+		}
+		if !errors.Is(err, apperr.ErrNotFound) {
+			return nil, err
+		}
+
+		// Never rolled out: the room gets whatever is in the main collection.
+		doc, err := uc.configStore.GetDocument(ctx, kind, gameName)
+		if err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		versions[kind] = doc.Meta().Version
+	}
+	return versions, nil
+}
@@ -0,0 +1,35 @@
+package scenario
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a manual clock. Time only moves when Advance or Sleep is called,
+// so a scenario decides when bullets expire and how long wallet retries
+// wait.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Sleep advances the clock instead of blocking.
+func (c *Clock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
@@ -0,0 +1,106 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+)
+
+// Expect adds a check that fails the run when it returns an error.
+func (s *Scenario) Expect(name string, check func(ctx context.Context, w *World) error) *Scenario {
+	return s.Step("expect "+name, check)
+}
+
+// ExpectBalance checks a seated player's balance in the room.
+func (s *Scenario) ExpectBalance(playerID string, want int64) *Scenario {
+	return s.Expect(playerID+" balance", func(ctx context.Context, w *World) error {
+		room, err := w.Store.Rooms.GetByID(ctx, w.RoomID)
+		if err != nil {
+			return err
+		}
+		player, ok := room.Players[playerID]
+		if !ok {
+			return fmt.Errorf("%s is not in room %s", playerID, w.RoomID)
+		}
+		if player.Balance != want {
+			return fmt.Errorf("balance = %d, want %d", player.Balance, want)
+		}
+		return nil
+	})
+}
+
+// ExpectWallet checks what a player's wallet holds.
+func (s *Scenario) ExpectWallet(playerID string, want int64) *Scenario {
+	return s.Expect(playerID+" wallet", func(ctx context.Context, w *World) error {
+		balance, err := w.Wallet.GetBalance(ctx, playerID)
+		if err != nil {
+			return err
+		}
+		if balance != want {
+			return fmt.Errorf("wallet = %d, want %d", balance, want)
+		}
+		return nil
+	})
+}
+
+// ExpectRTP checks the room's RTP totals.
+func (s *Scenario) ExpectRTP(totalBet, totalWin int64) *Scenario {
+	return s.Expect("RTP", func(ctx context.Context, w *World) error {
+		state, err := w.RTP.GetState(ctx, w.RoomID)
+		if err != nil {
+			return err
+		}
+		if state.TotalBet != totalBet || state.TotalWin != totalWin {
+			return fmt.Errorf("RTP bet/win = %d/%d, want %d/%d", state.TotalBet, state.TotalWin, totalBet, totalWin)
+		}
+		return nil
+	})
+}
+
+// ExpectLedger checks that the room's books agree with each other,
+// whatever the rolls were: balances rebuilt from the event log match the
+// live ones, and the RTP totals match the bets, refunds and wins the log
+// records.
+func (s *Scenario) ExpectLedger() *Scenario {
+	return s.Expect("ledger", func(ctx context.Context, w *World) error {
+		room, err := w.Store.Rooms.GetByID(ctx, w.RoomID)
+		if err != nil {
+			return err
+		}
+		replay, err := w.Replay.Replay(ctx, w.RoomID, 0)
+		if err != nil {
+			return err
+		}
+		for playerID, player := range room.Players {
+			if replay.Balances[playerID] != player.Balance {
+				return fmt.Errorf("%s: replayed balance %d, live balance %d", playerID, replay.Balances[playerID], player.Balance)
+			}
+		}
+
+		events, err := w.Store.Events.List(ctx, w.RoomID, 1, 0)
+		if err != nil {
+			return err
+		}
+		var bet, win int64
+		for _, e := range events {
+			if e.Type != entity.EventBalanceChanged {
+				continue
+			}
+			switch e.Reason {
+			case entity.BalanceReasonBet, entity.BalanceReasonRefund:
+				bet -= e.Amount
+			case entity.BalanceReasonWin:
+				win += e.Amount
+			}
+		}
+		state, err := w.RTP.GetState(ctx, w.RoomID)
+		if err != nil {
+			return err
+		}
+		if state.TotalBet != bet || state.TotalWin != win {
+			return fmt.Errorf("RTP bet/win = %d/%d, event log says %d/%d", state.TotalBet, state.TotalWin, bet, win)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,281 @@
+// Package scenario scripts games against the real usecases running on
+// in-memory repositories, so the math and the state rules can be
+// regression-tested without MongoDB, Redis or a wallet service:
+//
+//	scenario.New("boss fight").
+//		Gun(entity.Gun{GunID: 1, BulletCost: 10, Damage: 20, FireRateMs: 200}).
+//		FishType(entity.FishType{FishID: 9, BaseHP: 2000, Reward: 500, HitRate: 0.3, IsBoss: true}).
+//		CreateRoom("room-1", 4).
+//		Join("p1", 0, 5000, 1).
+//		Spawn(9, "boss").
+//		FireAt("p1", "boss", 200).
+//		ExpectLedger().
+//		Run(t)
+//
+// Runs are deterministic: hit rolls and fair-session seeds come from the
+// scenario's seed, and time only moves when a step moves it.
+package scenario
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap/zaptest"
+)
+
+// Scenario is a list of steps run in order against a fresh World.
+type Scenario struct {
+	name          string
+	seed          int64
+	walletBalance int64
+	steps         []step
+}
+
+type step struct {
+	name string
+	run  func(ctx context.Context, w *World) error
+}
+
+// New starts a scenario with seed 1 and wallets holding 1,000,000.
+func New(name string) *Scenario {
+	return &Scenario{name: name, seed: 1, walletBalance: 1_000_000}
+}
+
+// Seed sets the seed hit rolls and fair-session seeds derive from.
+func (s *Scenario) Seed(seed int64) *Scenario {
+	s.seed = seed
+	return s
+}
+
+// WalletBalance sets what every player's wallet holds before buying in.
+func (s *Scenario) WalletBalance(balance int64) *Scenario {
+	s.walletBalance = balance
+	return s
+}
+
+// Step adds a custom step.
+func (s *Scenario) Step(name string, run func(ctx context.Context, w *World) error) *Scenario {
+	s.steps = append(s.steps, step{name: name, run: run})
+	return s
+}
+
+// Run plays the scenario on a new World, failing t at the first step that
+// fails, and returns the World for further checks. Usecase logs go to t.
+func (s *Scenario) Run(t testing.TB) *World {
+	t.Helper()
+	w := NewWorld(s.seed, s.walletBalance)
+	ctx := logger.NewContext(context.Background(), zaptest.NewLogger(t))
+	for i, st := range s.steps {
+		if err := st.run(ctx, w); err != nil {
+			t.Fatalf("%s: step %d (%s): %v", s.name, i+1, st.name, err)
+		}
+	}
+	return w
+}
+
+// FishType adds a fish type to the catalog.
+func (s *Scenario) FishType(fishType entity.FishType) *Scenario {
+	return s.Step(fmt.Sprintf("fish type %d", fishType.FishID), func(_ context.Context, w *World) error {
+		w.Store.Fish.SaveType(&fishType)
+		return nil
+	})
+}
+
+// Gun adds a gun to the catalog.
+func (s *Scenario) Gun(gun entity.Gun) *Scenario {
+	return s.Step(fmt.Sprintf("gun %d", gun.GunID), func(_ context.Context, w *World) error {
+		w.Store.Guns.Save(&gun)
+		return nil
+	})
+}
+
+// GameConfig stores game config documents, each as the first version of
+// its kind.
+func (s *Scenario) GameConfig(docs ...gameBaseModels.ConfigDocument) *Scenario {
+	return s.Step("game config", func(ctx context.Context, w *World) error {
+		for _, doc := range docs {
+			if err := w.Store.GameConfig.SaveDocument(ctx, doc, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RollOutGameConfig writes doc as the next version of its kind through the
+// config admin usecase and rolls it out to percent of new rooms.
+func (s *Scenario) RollOutGameConfig(doc gameBaseModels.ConfigDocument, percent int) *Scenario {
+	return s.Step(fmt.Sprintf("roll out %s to %d%%", doc.Kind(), percent), func(ctx context.Context, w *World) error {
+		body, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		_, err = w.GameConfig.PutDocument(ctx, doc.Kind(), doc.Meta().GameName, "scenario", percent, body)
+		return err
+	})
+}
+
+// CreateRoom opens a room without a game config; later steps play in it.
+func (s *Scenario) CreateRoom(roomID string, maxPlayers int) *Scenario {
+	return s.createRoom(roomID, "", maxPlayers, false)
+}
+
+// CreateFairRoom opens a provably-fair room; later steps play in it.
+func (s *Scenario) CreateFairRoom(roomID string, maxPlayers int) *Scenario {
+	return s.createRoom(roomID, "", maxPlayers, true)
+}
+
+// CreateGameRoom opens a room that plays by a game's config; later steps
+// play in it.
+func (s *Scenario) CreateGameRoom(roomID, gameName string, maxPlayers int) *Scenario {
+	return s.createRoom(roomID, gameName, maxPlayers, false)
+}
+
+func (s *Scenario) createRoom(roomID, gameName string, maxPlayers int, provablyFair bool) *Scenario {
+	return s.Step("create room "+roomID, func(ctx context.Context, w *World) error {
+		if _, err := w.Room.CreateRoom(ctx, roomID, gameName, maxPlayers, provablyFair); err != nil {
+			return err
+		}
+		w.RoomID = roomID
+		return nil
+	})
+}
+
+// Join seats a player holding gunID and buys in from their wallet.
+func (s *Scenario) Join(playerID string, seatID int, buyIn int64, gunID int) *Scenario {
+	return s.Step("join "+playerID, func(ctx context.Context, w *World) error {
+		// Players pick their gun before joining; there is no usecase for it.
+		player, err := w.Store.Players.GetByID(ctx, playerID)
+		if errors.Is(err, apperr.ErrNotFound) {
+			player, err = &entity.Player{PlayerID: playerID}, nil
+		}
+		if err != nil {
+			return err
+		}
+		player.GunID = gunID
+		if err := w.Store.Players.Save(ctx, player); err != nil {
+			return err
+		}
+		_, _, err = w.Room.JoinRoom(ctx, w.RoomID, playerID, seatID, buyIn, "")
+		return err
+	})
+}
+
+// ChangeGun switches the gun the player fires with.
+func (s *Scenario) ChangeGun(playerID string, gunID int) *Scenario {
+	return s.Step(fmt.Sprintf("%s changes to gun %d", playerID, gunID), func(ctx context.Context, w *World) error {
+		_, err := w.Shoot.ChangeGun(ctx, w.RoomID, playerID, gunID)
+		return err
+	})
+}
+
+// Leave cashes the player out to their wallet.
+func (s *Scenario) Leave(playerID string) *Scenario {
+	return s.Step("leave "+playerID, func(ctx context.Context, w *World) error {
+		_, _, err := w.Room.LeaveRoom(ctx, w.RoomID, playerID)
+		return err
+	})
+}
+
+// UseSkill has the player use skill.
+func (s *Scenario) UseSkill(playerID string, skill entity.Skill) *Scenario {
+	return s.Step(fmt.Sprintf("%s uses %s", playerID, skill.SkillType), func(ctx context.Context, w *World) error {
+		return w.Skill.UseSkill(ctx, playerID, &skill)
+	})
+}
+
+// Spawn puts a fish of type fishID into the room.
+func (s *Scenario) Spawn(fishID int, fishUID string) *Scenario {
+	return s.Step("spawn "+fishUID, func(ctx context.Context, w *World) error {
+		_, err := w.Fish.SpawnFish(ctx, w.RoomID, fishID, fishUID, 1)
+		return err
+	})
+}
+
+// FireAt fires shots bullets at a fish, resolving each before the next is
+// fired. The clock advances by the gun's fire rate between shots. Shots at
+// a fish that is already dead are misses, as in play.
+func (s *Scenario) FireAt(playerID, fishUID string, shots int) *Scenario {
+	return s.Step(fmt.Sprintf("%s fires %d at %s", playerID, shots, fishUID), func(ctx context.Context, w *World) error {
+		for i := 0; i < shots; i++ {
+			fired, err := w.fire(ctx, playerID)
+			if err != nil {
+				return fmt.Errorf("shot %d: %w", i+1, err)
+			}
+			result, err := w.Shoot.Hit(ctx, w.RoomID, playerID, fired.Shot.BulletID, fishUID)
+			if err != nil {
+				return fmt.Errorf("shot %d: %w", i+1, err)
+			}
+			stats := w.playerStats(playerID)
+			stats.Won += result.Reward
+			if result.Hit {
+				stats.Hits++
+			}
+			if result.Reward > 0 {
+				stats.Kills++
+			}
+			if gun, err := w.Store.Guns.GetByID(ctx, fired.Shot.GunID); err == nil {
+				w.Clock.Advance(time.Duration(gun.FireRateMs) * time.Millisecond)
+			}
+		}
+		return nil
+	})
+}
+
+// Fire fires shots bullets that are left in flight, to be settled by expiry
+// or by the player leaving.
+func (s *Scenario) Fire(playerID string, shots int) *Scenario {
+	return s.Step(fmt.Sprintf("%s fires %d", playerID, shots), func(ctx context.Context, w *World) error {
+		for i := 0; i < shots; i++ {
+			if _, err := w.fire(ctx, playerID); err != nil {
+				return fmt.Errorf("shot %d: %w", i+1, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (w *World) fire(ctx context.Context, playerID string) (*entity.ShotResult, error) {
+	w.bullets[playerID]++
+	bulletID := fmt.Sprintf("%s-%d", playerID, w.bullets[playerID])
+	result, err := w.Shoot.Fire(ctx, w.RoomID, playerID, bulletID)
+	if err != nil {
+		return nil, err
+	}
+	stats := w.playerStats(playerID)
+	stats.Fired++
+	stats.Bet += result.Cost
+	return result, nil
+}
+
+// Advance moves the clock forward.
+func (s *Scenario) Advance(d time.Duration) *Scenario {
+	return s.Step("advance "+d.String(), func(_ context.Context, w *World) error {
+		w.Clock.Advance(d)
+		return nil
+	})
+}
+
+// SweepBullets runs the background sweep of expired bullets.
+func (s *Scenario) SweepBullets() *Scenario {
+	return s.Step("sweep bullets", func(ctx context.Context, w *World) error {
+		_, err := w.Shoot.SweepExpiredBullets(ctx)
+		return err
+	})
+}
+
+// ExpireBullets settles the room's bullets whose time is up.
+func (s *Scenario) ExpireBullets() *Scenario {
+	return s.Step("expire bullets", func(ctx context.Context, w *World) error {
+		_, err := w.Shoot.ExpireBullets(ctx, w.RoomID)
+		return err
+	})
+}
@@ -0,0 +1,517 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/adapter/rng"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
+)
+
+var (
+	cannon = entity.Gun{GunID: 1, BulletCost: 10, Damage: 20, FireRateMs: 200}
+	laser  = entity.Gun{GunID: 2, BulletCost: 50, Damage: 120, FireRateMs: 400}
+	minnow = entity.FishType{FishID: 1, BaseHP: 20, Reward: 30, HitRate: 0.5, Speed: 1}
+	kraken = entity.FishType{FishID: 9, BaseHP: 2000, Reward: 1500, HitRate: 0.4, Speed: 0.3, IsBoss: true}
+)
+
+func bossFight(seed int64) *Scenario {
+	return New("boss fight").
+		Seed(seed).
+		Gun(cannon).
+		FishType(minnow).
+		FishType(kraken).
+		CreateRoom("room-1", 4).
+		Join("p1", 0, 5000, 1).
+		Join("p2", 1, 5000, 1).
+		Join("p3", 2, 5000, 1).
+		Spawn(9, "boss").
+		FireAt("p1", "boss", 200).
+		FireAt("p2", "boss", 50).
+		Spawn(1, "minnow").
+		FireAt("p3", "minnow", 20)
+}
+
+// The outcome of seed 42 is pinned: a change here means the hit math, the
+// RNG or the settlement rules changed.
+func TestBossFight(t *testing.T) {
+	w := bossFight(42).
+		ExpectBalance("p1", 3000).
+		ExpectBalance("p2", 6000).
+		ExpectBalance("p3", 4830).
+		ExpectRTP(2700, 1530).
+		ExpectLedger().
+		Run(t)
+
+	if got := w.Stats("p2"); got.Kills != 1 || got.Won != 1500 {
+		t.Fatalf("p2 = %+v, want the boss kill", got)
+	}
+	if got := w.Stats("p1"); got.Fired != 200 || got.Kills != 0 {
+		t.Fatalf("p1 = %+v, want 200 shots and no kill", got)
+	}
+}
+
+func TestSameSeedPlaysOutTheSame(t *testing.T) {
+	first := bossFight(7).ExpectLedger().Run(t)
+	second := bossFight(7).ExpectLedger().Run(t)
+	for _, p := range []string{"p1", "p2", "p3"} {
+		if first.Stats(p) != second.Stats(p) {
+			t.Fatalf("%s: %+v then %+v", p, first.Stats(p), second.Stats(p))
+		}
+	}
+}
+
+func TestExpiredBulletsAreRefunded(t *testing.T) {
+	New("expiry").
+		Gun(cannon).
+		CreateRoom("room-1", 4).
+		Join("p1", 0, 1000, 1).
+		Fire("p1", 3).
+		ExpectBalance("p1", 970).
+		Advance(time.Duration(entity.DefaultBulletTTLMs-1)*time.Millisecond).
+		ExpireBullets().
+		ExpectBalance("p1", 970).
+		Advance(time.Millisecond).
+		ExpireBullets().
+		ExpectBalance("p1", 1000).
+		ExpectRTP(0, 0).
+		ExpectLedger().
+		Run(t)
+}
+
+func TestSweepRefundsIdlePlayers(t *testing.T) {
+	New("sweep").
+		Gun(cannon).
+		CreateRoom("room-1", 4).
+		Join("p1", 0, 1000, 1).
+		Fire("p1", 3).
+		Advance(time.Duration(entity.DefaultBulletTTLMs)*time.Millisecond).
+		SweepBullets().
+		ExpectBalance("p1", 1000).
+		ExpectRTP(0, 0).
+		ExpectLedger().
+		Run(t)
+}
+
+// The sweep finds rooms through storage, so an instance that never fired in
+// a room still refunds its bullets, e.g. after the one that did has stopped.
+func TestSweepOnAnotherInstanceRefundsIdlePlayers(t *testing.T) {
+	New("sweep elsewhere").
+		Gun(cannon).
+		CreateRoom("room-1", 4).
+		Join("p1", 0, 1000, 1).
+		Fire("p1", 3).
+		Advance(time.Duration(entity.DefaultBulletTTLMs)*time.Millisecond).
+		Step("another instance sweeps", func(ctx context.Context, w *World) error {
+			s := w.Store
+			other := usecase.NewShootUsecase(s.Rooms, s.Players, s.Fish, s.Guns, s.GameConfig, s.GameConfigVersions, s.RTP, s.ShotResults,
+				rng.NewSeededRNG(1, zap.NewNop()), s.FairSessions, s.FairShots, s.Events, nil, usecase.WithClock(w.Clock.Now), usecase.WithPublisher(w))
+			settled, err := other.SweepExpiredBullets(ctx)
+			if err == nil && settled != 3 {
+				err = fmt.Errorf("settled %d bullets, want 3", settled)
+			}
+			return err
+		}).
+		ExpectBalance("p1", 1000).
+		ExpectRTP(0, 0).
+		ExpectLedger().
+		Run(t)
+}
+
+func TestLeavingRefundsBulletsInFlight(t *testing.T) {
+	w := New("leave in flight").
+		WalletBalance(10_000).
+		Gun(cannon).
+		CreateRoom("room-1", 4).
+		Join("p1", 0, 1000, 1).
+		Fire("p1", 3).
+		Leave("p1").
+		ExpectRTP(0, 0).
+		Run(t)
+
+	balance, err := w.Wallet.GetBalance(context.Background(), "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 10_000 {
+		t.Fatalf("wallet after leaving = %d, want the bullets refunded", balance)
+	}
+}
+
+func TestMutationsArePublishedInSeqOrder(t *testing.T) {
+	w := New("publish").
+		Gun(cannon).
+		FishType(minnow).
+		CreateRoom("room-1", 4).
+		Join("p1", 0, 1000, 1).
+		Spawn(1, "minnow").
+		FireAt("p1", "minnow", 5).
+		Leave("p1").
+		Run(t)
+
+	// Create, join, spawn, 5 fires, 5 hits or fewer and leave.
+	if len(w.Published) < 9 {
+		t.Fatalf("published %d batches, want one per mutation", len(w.Published))
+	}
+	room, err := w.Store.Rooms.GetByID(context.Background(), "room-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := int64(0)
+	for _, p := range w.Published {
+		if p.Seq <= last {
+			t.Fatalf("batch at seq %d published after seq %d", p.Seq, last)
+		}
+		for _, e := range p.Events {
+			if e.RoomSeq != p.Seq {
+				t.Fatalf("%s event carries seq %d in a batch at seq %d", e.Type, e.RoomSeq, p.Seq)
+			}
+		}
+		last = p.Seq
+	}
+	if last != room.Seq {
+		t.Fatalf("last published seq %d, room is at %d", last, room.Seq)
+	}
+}
+
+func TestLeavingCashesOut(t *testing.T) {
+	w := New("cash out").
+		WalletBalance(10_000).
+		Gun(cannon).
+		FishType(minnow).
+		CreateRoom("room-1", 4).
+		Join("p1", 0, 2000, 1).
+		ExpectWallet("p1", 8000).
+		Spawn(1, "minnow").
+		FireAt("p1", "minnow", 10).
+		ExpectLedger().
+		Leave("p1").
+		Run(t)
+
+	stats := w.Stats("p1")
+	balance, err := w.Wallet.GetBalance(context.Background(), "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 10_000 - stats.Bet + stats.Won; balance != want {
+		t.Fatalf("wallet after leaving = %d, want %d (%+v)", balance, want, stats)
+	}
+}
+
+// A game room prices bullets and pays kills from the game's config rather
+// than the gun and fish type catalogues, at the odds the simulator plays.
+func TestGameRoomPlaysByItsConfig(t *testing.T) {
+	w := New("game room").
+		Gun(cannon).
+		GameConfig(reef(1, 5)...).
+		CreateGameRoom("room-1", "reef", 4).
+		Join("p1", 0, 5000, 1).
+		Spawn(1, "minnow").
+		FireAt("p1", "minnow", 100).
+		ExpectLedger().
+		Run(t)
+
+	if got := w.Stats("p1"); got.Bet != 100*5 || got.Kills != 1 || got.Won != 60 {
+		t.Fatalf("p1 = %+v, want 100 shots at 5 and one kill paying 60", got)
+	}
+}
+
+// A running room keeps the config versions it was created with; only rooms
+// created after a rollout play the new version.
+func TestRoomsKeepTheirPinnedConfig(t *testing.T) {
+	w := New("pinned config").
+		Gun(cannon).
+		GameConfig(reef(1, 5)...).
+		CreateGameRoom("room-1", "reef", 4).
+		RollOutGameConfig(reef(0, 8)[0], 100).
+		Join("p1", 0, 5000, 1).
+		Spawn(1, "minnow").
+		FireAt("p1", "minnow", 10).
+		CreateGameRoom("room-2", "reef", 4).
+		Join("p2", 0, 5000, 1).
+		Spawn(1, "minnow").
+		FireAt("p2", "minnow", 10).
+		ExpectLedger().
+		Run(t)
+
+	if got := w.Stats("p1").Bet; got != 10*5 {
+		t.Fatalf("p1 bet = %d in the room pinned to v1, want %d", got, 10*5)
+	}
+	if got := w.Stats("p2").Bet; got != 10*8 {
+		t.Fatalf("p2 bet = %d in the room created after v2, want %d", got, 10*8)
+	}
+}
+
+// reef is a one-bullet, one-fish game whose bullet costs cost, as documents
+// at version.
+func reef(version int64, cost int) []gameBaseModels.ConfigDocument {
+	meta := gameBaseModels.ConfigMeta{GameName: "reef", Version: version}
+	return []gameBaseModels.ConfigDocument{
+		&gameBaseModels.BulletConfig{ConfigMeta: meta, Data: gameBaseModels.BulletData{Bullets: []gameBaseModels.BulletInfo{
+			{BulletID: 1, Name: "cannon", Cost: cost, Damage: 20},
+		}}},
+		&gameBaseModels.GameRTP{ConfigMeta: meta, Data: gameBaseModels.RTPData{RTPRate: 100}},
+		&gameBaseModels.GameFishTypes{ConfigMeta: meta, Data: gameBaseModels.FishTypeData{FishTypes: []gameBaseModels.FishType{
+			{FishID: 1, FishName: "minnow", HP: 20, BaseReward: 30, Rarity: "common", SpawnRate: 100, Multiplier: 2},
+		}}},
+	}
+}
+
+func TestChangingGunChangesTheBet(t *testing.T) {
+	w := New("gun change").
+		Gun(cannon).
+		Gun(laser).
+		FishType(kraken).
+		CreateRoom("room-1", 4).
+		Join("p1", 0, 5000, 1).
+		Spawn(9, "boss").
+		FireAt("p1", "boss", 10).
+		ChangeGun("p1", 2).
+		FireAt("p1", "boss", 10).
+		ExpectLedger().
+		Run(t)
+
+	if got := w.Stats("p1").Bet; got != 10*10+10*50 {
+		t.Fatalf("bet = %d, want %d", got, 10*10+10*50)
+	}
+	player, err := w.Store.Players.GetByID(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if player.GunID != laser.GunID {
+		t.Fatalf("stored gun = %d, want %d", player.GunID, laser.GunID)
+	}
+}
+
+// Skills are charged on the seat that shots are charged on, so neither
+// overwrites the other and the resync snapshot shows both.
+func TestSkillsAndShotsShareTheSeatBalance(t *testing.T) {
+	freeze := entity.Skill{SkillType: "freeze", Cost: 100, CooldownMs: 30_000}
+	w := New("skill then shot").
+		Gun(cannon).
+		CreateRoom("room-1", 4).
+		Join("p1", 0, 1000, 1).
+		UseSkill("p1", freeze).
+		Fire("p1", 1).
+		ExpectBalance("p1", 890).
+		ExpectLedger().
+		Run(t)
+
+	ctx := context.Background()
+	player, err := w.Store.Players.GetByID(ctx, "p1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if player.Balance != 890 {
+		t.Fatalf("player record balance = %d, want 890", player.Balance)
+	}
+	snapshot, err := w.Sync.Snapshot(ctx, "room-1", "p1")
+	if err != nil {
+		t.Fatalf("Snapshot: %v", err)
+	}
+	if snapshot.Self.Balance != 890 {
+		t.Fatalf("snapshot balance = %d, want 890", snapshot.Self.Balance)
+	}
+	if len(snapshot.Self.Cooldowns) != 1 || snapshot.Self.Cooldowns[0].RemainingMs != 30_000 {
+		t.Fatalf("snapshot cooldowns = %+v, want freeze for 30s", snapshot.Self.Cooldowns)
+	}
+	if err := w.Skill.UseSkill(ctx, "p1", &freeze); !errors.Is(err, apperr.ErrSkillOnCooldown) {
+		t.Fatalf("second freeze: err = %v, want ErrSkillOnCooldown", err)
+	}
+}
+
+// Players firing at the same moment race to save the room. Every bullet
+// that lands in the room must be charged, whatever order the saves land in.
+func TestConcurrentShotsAreAllCharged(t *testing.T) {
+	players := []string{"p1", "p2", "p3", "p4"}
+	const shots = 25
+	landed := map[string]int{}
+
+	s := New("crossfire").Gun(cannon).CreateRoom("room-1", 4)
+	for seat, playerID := range players {
+		s = s.Join(playerID, seat, 1000, 1)
+	}
+	w := s.Step("everyone fires at once", func(ctx context.Context, w *World) error {
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, playerID := range players {
+			wg.Add(1)
+			go func(playerID string) {
+				defer wg.Done()
+				for i := 1; i <= shots; i++ {
+					_, err := w.Shoot.Fire(ctx, w.RoomID, playerID, fmt.Sprintf("%s-%d", playerID, i))
+					// A shot may still lose every attempt; the player retries it.
+					if errors.Is(err, apperr.ErrRoomConflict) {
+						continue
+					}
+					if err != nil {
+						t.Errorf("%s shot %d: %v", playerID, i, err)
+						return
+					}
+					mu.Lock()
+					landed[playerID]++
+					mu.Unlock()
+				}
+			}(playerID)
+		}
+		wg.Wait()
+		return nil
+	}).Run(t)
+
+	room, err := w.Store.Rooms.GetByID(context.Background(), "room-1")
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	total := 0
+	for _, playerID := range players {
+		total += landed[playerID]
+		want := int64(1000 - landed[playerID]*cannon.BulletCost)
+		if got := room.Players[playerID].Balance; got != want {
+			t.Errorf("%s balance = %d after %d shots, want %d", playerID, got, landed[playerID], want)
+		}
+		if got := room.LiveBulletCount(playerID); got != landed[playerID] {
+			t.Errorf("%s has %d bullets in flight, want %d", playerID, got, landed[playerID])
+		}
+	}
+	if total == 0 {
+		t.Fatal("no shot landed")
+	}
+}
+
+// Hits in a provably-fair room race to save the room like any other shot.
+// A hit that is redone rolls its nonce again, so every nonce is spent on
+// exactly one recorded shot and each of them verifies once the player has
+// left and the seed is revealed.
+func TestFairShotsVerifyAfterLeaving(t *testing.T) {
+	players := []string{"p1", "p2"}
+	const shots = 10
+	fired := map[string][]string{}
+	landed := map[string]uint64{}
+	sessions := map[string]string{}
+
+	s := New("fair crossfire").Gun(cannon).FishType(kraken).CreateFairRoom("room-1", 2)
+	for seat, playerID := range players {
+		s = s.Join(playerID, seat, 1000, 1)
+	}
+	s.Spawn(9, "boss").
+		Step("everyone fires", func(ctx context.Context, w *World) error {
+			for _, playerID := range players {
+				for i := 0; i < shots; i++ {
+					result, err := w.fire(ctx, playerID)
+					if err != nil {
+						return err
+					}
+					fired[playerID] = append(fired[playerID], result.Shot.BulletID)
+				}
+			}
+			return nil
+		}).
+		Step("every bullet lands at once", func(ctx context.Context, w *World) error {
+			var mu sync.Mutex
+			var wg sync.WaitGroup
+			for _, playerID := range players {
+				wg.Add(1)
+				go func(playerID string) {
+					defer wg.Done()
+					for _, bulletID := range fired[playerID] {
+						_, err := w.Shoot.Hit(ctx, w.RoomID, playerID, bulletID, "boss")
+						if errors.Is(err, apperr.ErrRoomConflict) {
+							continue
+						}
+						if err != nil {
+							t.Errorf("%s hit %s: %v", playerID, bulletID, err)
+							return
+						}
+						mu.Lock()
+						landed[playerID]++
+						mu.Unlock()
+					}
+				}(playerID)
+			}
+			wg.Wait()
+			return nil
+		}).
+		Step("sessions count the landed hits", func(ctx context.Context, w *World) error {
+			for _, playerID := range players {
+				session, err := w.Fairness.ActiveSession(ctx, w.RoomID, playerID)
+				if err != nil {
+					return err
+				}
+				if session.Nonce != landed[playerID] {
+					return fmt.Errorf("%s next nonce = %d after %d hits", playerID, session.Nonce, landed[playerID])
+				}
+				sessions[playerID] = session.SessionID
+			}
+			return nil
+		}).
+		Leave("p1").
+		Leave("p2").
+		Step("every shot verifies", func(ctx context.Context, w *World) error {
+			for _, playerID := range players {
+				session, err := w.Fairness.Session(ctx, sessions[playerID])
+				if err != nil {
+					return err
+				}
+				if !session.IsRevealed() || session.Nonce != landed[playerID] {
+					return fmt.Errorf("%s session = %+v, want revealed at nonce %d", playerID, session, landed[playerID])
+				}
+				for nonce := uint64(0); nonce < landed[playerID]; nonce++ {
+					v, err := w.Fairness.Verify(ctx, session.SessionID, nonce)
+					if err != nil {
+						return fmt.Errorf("%s nonce %d: %w", playerID, nonce, err)
+					}
+					if !v.Match {
+						return fmt.Errorf("%s nonce %d does not verify: %+v", playerID, nonce, v)
+					}
+				}
+				if _, err := w.Fairness.Verify(ctx, session.SessionID, landed[playerID]); !errors.Is(err, apperr.ErrFairShotNotFound) {
+					return fmt.Errorf("%s nonce %d: err = %v, want ErrFairShotNotFound", playerID, landed[playerID], err)
+				}
+			}
+			return nil
+		}).
+		Run(t)
+}
+
+// brokenEvents is an event log that is down.
+type brokenEvents struct{}
+
+func (brokenEvents) Append(ctx context.Context, roomID string, events ...*entity.GameEvent) error {
+	return errors.New("event log unavailable")
+}
+
+func (brokenEvents) List(ctx context.Context, roomID string, fromSeq, toSeq int64) ([]*entity.GameEvent, error) {
+	return nil, errors.New("event log unavailable")
+}
+
+// Clients are only told about events the log holds. A shot whose events
+// cannot be recorded still stands, and is left for clients to resync.
+func TestUnrecordedEventsAreNotPublished(t *testing.T) {
+	New("event log down").
+		Gun(cannon).
+		CreateRoom("room-1", 4).
+		Join("p1", 0, 1000, 1).
+		Step("fire while the event log is down", func(ctx context.Context, w *World) error {
+			s := w.Store
+			shoot := usecase.NewShootUsecase(s.Rooms, s.Players, s.Fish, s.Guns, s.GameConfig, s.GameConfigVersions, s.RTP, s.ShotResults,
+				rng.NewSeededRNG(1, zap.NewNop()), s.FairSessions, s.FairShots, brokenEvents{}, nil, usecase.WithClock(w.Clock.Now), usecase.WithPublisher(w))
+			published := len(w.Published)
+			if _, err := shoot.Fire(ctx, w.RoomID, "p1", "p1-1"); err != nil {
+				return err
+			}
+			if len(w.Published) != published {
+				return fmt.Errorf("published %d batches the log does not hold", len(w.Published)-published)
+			}
+			return nil
+		}).
+		ExpectBalance("p1", 990).
+		Run(t)
+}
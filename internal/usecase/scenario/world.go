@@ -0,0 +1,122 @@
+package scenario
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/adapter/repository/memory"
+	"github.com/BT2701/backend-fishing-gameplay/adapter/rng"
+	"github.com/BT2701/backend-fishing-gameplay/adapter/wallet"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// Start is where every World's clock starts.
+var Start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// PlayerStats counts what a player's shots did, as seen by the scenario.
+type PlayerStats struct {
+	Fired int
+	Hits  int
+	Kills int
+	Bet   int64
+	Won   int64
+}
+
+// World is the game wired the way the server wires it, but over in-memory
+// repositories, a manual clock, a seeded RNG and an in-memory wallet, so the
+// same seed always plays out the same way.
+type World struct {
+	Store  *memory.Store
+	Clock  *Clock
+	Wallet port.WalletProvider
+
+	Room       *usecase.RoomUsecase
+	Fish       *usecase.FishUsecase
+	Shoot      *usecase.ShootUsecase
+	RTP        *usecase.RTPUsecase
+	Skill      *usecase.SkillUsecase
+	Sync       *usecase.SyncUsecase
+	Fairness   *usecase.FairnessUsecase
+	Replay     *usecase.ReplayUsecase
+	GameConfig *usecase.GameConfigUsecase
+
+	// RoomID is the room the last CreateRoom step opened; later steps play
+	// in it.
+	RoomID string
+
+	// Published is every batch of room events pushed to clients, in order.
+	Published   []Publication
+	publishedMu sync.Mutex
+
+	stats   map[string]*PlayerStats
+	bullets map[string]int
+}
+
+// Publication is one batch of room events pushed to clients.
+type Publication struct {
+	RoomID string
+	Seq    int64
+	Events []*entity.GameEvent
+}
+
+// Publish records a batch; it makes World the usecases' port.RoomPublisher.
+func (w *World) Publish(roomID string, seq int64, events []*entity.GameEvent) {
+	w.publishedMu.Lock()
+	defer w.publishedMu.Unlock()
+	w.Published = append(w.Published, Publication{RoomID: roomID, Seq: seq, Events: events})
+}
+
+// NewWorld builds a World whose hit rolls and fair-session seeds derive from
+// seed and whose wallet gives every new player walletBalance.
+func NewWorld(seed, walletBalance int64) *World {
+	store := memory.NewStore()
+	clock := NewClock(Start)
+	wallet := wallet.NewMemoryWalletProvider(walletBalance)
+	w := &World{
+		Store:   store,
+		Clock:   clock,
+		Wallet:  wallet,
+		stats:   map[string]*PlayerStats{},
+		bullets: map[string]int{},
+	}
+	gameRNG := rng.NewSeededRNG(seed, zap.NewNop())
+	opts := []usecase.Option{
+		usecase.WithClock(clock.Now),
+		usecase.WithSleep(clock.Sleep),
+		usecase.WithEntropy(rand.New(rand.NewSource(seed))),
+		usecase.WithPublisher(w),
+		usecase.WithReleasers(gameRNG),
+	}
+
+	w.Room = usecase.NewRoomUsecase(store.Rooms, store.Players, wallet, store.WalletTransfers, store.FairSessions, store.Events, store.RTP, store.GameConfig, store.GameConfigVersions, opts...)
+	w.Fish = usecase.NewFishUsecase(store.Rooms, store.Fish, store.GameConfig, store.GameConfigVersions, store.Events, opts...)
+	w.Shoot = usecase.NewShootUsecase(store.Rooms, store.Players, store.Fish, store.Guns, store.GameConfig, store.GameConfigVersions, store.RTP, store.ShotResults, gameRNG, store.FairSessions, store.FairShots, store.Events, nil, opts...)
+	w.RTP = usecase.NewRTPUsecase(store.RTP)
+	w.Skill = usecase.NewSkillUsecase(store.Rooms, store.Players, store.Events, opts...)
+	w.Sync = usecase.NewSyncUsecase(store.Rooms, store.Guns, opts...)
+	w.Fairness = usecase.NewFairnessUsecase(store.Rooms, store.FairSessions, store.FairShots)
+	w.Replay = usecase.NewReplayUsecase(store.Events)
+	w.GameConfig = usecase.NewGameConfigUsecase(store.GameConfig, store.GameConfig, store.GameConfigVersions, store.GameConfig, opts...)
+	return w
+}
+
+// Stats returns what the player's shots have done so far.
+func (w *World) Stats(playerID string) PlayerStats {
+	if s, ok := w.stats[playerID]; ok {
+		return *s
+	}
+	return PlayerStats{}
+}
+
+func (w *World) playerStats(playerID string) *PlayerStats {
+	s, ok := w.stats[playerID]
+	if !ok {
+		s = &PlayerStats{}
+		w.stats[playerID] = s
+	}
+	return s
+}
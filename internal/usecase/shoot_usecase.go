@@ -3,142 +3,687 @@ package usecase
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/models"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/games/game_base/sevices"
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
 	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// shotDedupeTTL bounds how long a bullet id is remembered. Client
+	// retries happen within seconds, so a few minutes is ample.
+	shotDedupeTTL = 5 * time.Minute
+
+	// sweepBatch bounds the rooms one SweepExpiredBullets run settles.
+	sweepBatch = 100
 )
 
 type ShootUsecase struct {
-	roomRepo   port.RoomRepository
-	playerRepo port.PlayerRepository
-	fishRepo   port.FishRepository
-	gunRepo    port.GunRepository
-	rtpRepo    port.RTPRepository
-	now        func() time.Time
+	roomRepo        port.RoomRepository
+	playerRepo      port.PlayerRepository
+	fishRepo        port.FishRepository
+	gunRepo         port.GunRepository
+	configs         *pinnedConfigs
+	rtpRepo         port.RTPRepository
+	shotResultRepo  port.ShotResultRepository
+	rng             port.RNG
+	fairSessionRepo port.FairSessionRepository
+	fairShotRepo    port.FairShotRepository
+	events          port.EventStore
+	shotLogs        *logger.Sampler
+	publisher       port.RoomPublisher
+	metrics         port.GameplayMetrics
+	now             func() time.Time
 }
 
-func NewShootUsecase(roomRepo port.RoomRepository, playerRepo port.PlayerRepository, fishRepo port.FishRepository, gunRepo port.GunRepository, rtpRepo port.RTPRepository) *ShootUsecase {
+func NewShootUsecase(roomRepo port.RoomRepository, playerRepo port.PlayerRepository, fishRepo port.FishRepository, gunRepo port.GunRepository, configStore port.GameConfigStore, configVersions port.GameConfigVersionStore, rtpRepo port.RTPRepository, shotResultRepo port.ShotResultRepository, rng port.RNG, fairSessionRepo port.FairSessionRepository, fairShotRepo port.FairShotRepository, events port.EventStore, shotLogs *logger.Sampler, opts ...Option) *ShootUsecase {
+	o := newOptions(opts)
 	return &ShootUsecase{
-		roomRepo:   roomRepo,
-		playerRepo: playerRepo,
-		fishRepo:   fishRepo,
-		gunRepo:    gunRepo,
-		rtpRepo:    rtpRepo,
-		now:        time.Now,
+		roomRepo:        roomRepo,
+		playerRepo:      playerRepo,
+		fishRepo:        fishRepo,
+		gunRepo:         gunRepo,
+		configs:         newPinnedConfigs(configStore, configVersions),
+		rtpRepo:         rtpRepo,
+		shotResultRepo:  shotResultRepo,
+		rng:             rng,
+		fairSessionRepo: fairSessionRepo,
+		fairShotRepo:    fairShotRepo,
+		events:          events,
+		shotLogs:        shotLogs,
+		publisher:       o.publisher,
+		metrics:         o.metrics,
+		now:             o.now,
+	}
+}
+
+// Fire charges the player for a bullet and registers it as in flight until
+// Hit resolves it or it expires. bulletID is supplied by the client;
+// repeating a request with the same bullet id returns the original result
+// without charging again.
+func (uc *ShootUsecase) Fire(ctx context.Context, roomID, playerID, bulletID string) (_ *entity.ShotResult, err error) {
+	ctx, span := startSpan(ctx, "ShootUsecase.Fire", roomAttr(roomID), playerAttr(playerID))
+	defer endSpan(span, &err)
+	ctx = logContext(ctx, roomID, playerID)
+	defer func() {
+		if err != nil {
+			uc.logShotFailure(ctx, "Shot rejected", err, zap.String("bullet_id", bulletID))
+		}
+	}()
+
+	if roomID == "" {
+		return nil, apperr.ErrInvalidRoomID
+	}
+	if playerID == "" {
+		return nil, apperr.ErrInvalidPlayerID
+	}
+	if !entity.IsValidBulletID(bulletID) {
+		return nil, apperr.ErrInvalidBulletID
+	}
+
+	claimed, previous, err := uc.shotResultRepo.Claim(ctx, playerID, bulletID, shotDedupeTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		if previous == nil {
+			return nil, apperr.ErrShotInProgress
+		}
+		if previous.RoomID != roomID {
+			return nil, apperr.ErrBulletIDConflict
+		}
+		previous.Replayed = true
+		uc.logShot(ctx, zapcore.DebugLevel, "Shot replayed", zap.String("bullet_id", bulletID))
+		return previous, nil
+	}
+
+	// A result means the charge was persisted and the claim completed, so
+	// the claim is only released when there is none.
+	var result *entity.ShotResult
+	err = retryRoomConflict(ctx, func() (err error) {
+		result, err = uc.fire(ctx, roomID, playerID, bulletID)
+		return err
+	})
+	if result == nil {
+		_ = uc.shotResultRepo.Release(ctx, playerID, bulletID)
+	}
+	if err != nil {
+		return nil, err
 	}
+	uc.logShot(ctx, zapcore.DebugLevel, "Shot fired",
+		zap.String("bullet_id", bulletID),
+		zap.Int("gun_id", result.Shot.GunID),
+		zap.Int64("cost", result.Cost),
+		zap.Int64("balance", result.Player.Balance),
+	)
+	return result, nil
 }
 
-func (uc *ShootUsecase) Fire(ctx context.Context, roomID, playerID, fishUID string) (*entity.Shot, *entity.FishInstance, *entity.Player, error) {
+// Hit resolves an in-flight bullet against the fish the client reports it
+// collided with. A bullet whose fish is gone or already dead counts as a
+// miss. Replays with the same bullet id return the original resolution.
+func (uc *ShootUsecase) Hit(ctx context.Context, roomID, playerID, bulletID, fishUID string) (_ *entity.ShotResult, err error) {
+	ctx, span := startSpan(ctx, "ShootUsecase.Hit", roomAttr(roomID), playerAttr(playerID))
+	defer endSpan(span, &err)
+	ctx = logContext(ctx, roomID, playerID)
+	defer func() {
+		if err != nil {
+			uc.logShotFailure(ctx, "Hit rejected", err, zap.String("bullet_id", bulletID), zap.String("fish_uid", fishUID))
+		}
+	}()
+
 	if roomID == "" {
-		return nil, nil, nil, apperr.ErrInvalidRoomID
+		return nil, apperr.ErrInvalidRoomID
 	}
 	if playerID == "" {
-		return nil, nil, nil, apperr.ErrInvalidPlayerID
+		return nil, apperr.ErrInvalidPlayerID
+	}
+	if !entity.IsValidBulletID(bulletID) {
+		return nil, apperr.ErrInvalidBulletID
 	}
 	if fishUID == "" {
-		return nil, nil, nil, apperr.ErrInvalidFishUID
+		return nil, apperr.ErrInvalidFishUID
+	}
+
+	dedupeID := bulletID + ":hit"
+	claimed, previous, err := uc.shotResultRepo.Claim(ctx, playerID, dedupeID, shotDedupeTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		if previous == nil {
+			return nil, apperr.ErrShotInProgress
+		}
+		if previous.RoomID != roomID || previous.Shot.FishUID != fishUID {
+			return nil, apperr.ErrBulletIDConflict
+		}
+		previous.Replayed = true
+		uc.logShot(ctx, zapcore.DebugLevel, "Hit replayed", zap.String("bullet_id", bulletID))
+		return previous, nil
+	}
+
+	var result *entity.ShotResult
+	err = retryRoomConflict(ctx, func() (err error) {
+		result, err = uc.hit(ctx, roomID, playerID, bulletID, fishUID)
+		return err
+	})
+	if result == nil {
+		_ = uc.shotResultRepo.Release(ctx, playerID, dedupeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	uc.logHit(ctx, result)
+	return result, nil
+}
+
+// ExpireBullets settles every expired bullet in a room. Fire and Hit do this
+// lazily; SweepExpiredBullets does it for rooms where nobody is shooting.
+func (uc *ShootUsecase) ExpireBullets(ctx context.Context, roomID string) (settled int, err error) {
+	ctx, span := startSpan(ctx, "ShootUsecase.ExpireBullets", roomAttr(roomID))
+	defer endSpan(span, &err)
+	ctx = logContext(ctx, roomID, "")
+
+	err = retryRoomConflict(ctx, func() (err error) {
+		settled, err = uc.expireBullets(ctx, roomID)
+		return err
+	})
+	return settled, err
+}
+
+// SweepExpiredBullets settles expired bullets in every room the repository
+// reports as holding one, whichever instance fired them, so a player who
+// stops shooting is refunded without waiting for someone else to fire. It
+// returns the number of bullets settled.
+func (uc *ShootUsecase) SweepExpiredBullets(ctx context.Context) (int, error) {
+	roomIDs, err := uc.roomRepo.ListWithExpiredBullets(ctx, uc.now().UnixMilli(), sweepBatch)
+	if err != nil {
+		return 0, err
 	}
 
+	settled := 0
+	var firstErr error
+	for _, roomID := range roomIDs {
+		n, err := uc.ExpireBullets(ctx, roomID)
+		settled += n
+		if err != nil && !errors.Is(err, apperr.ErrRoomNotFound) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return settled, firstErr
+}
+
+// expireBullets is one attempt of ExpireBullets.
+func (uc *ShootUsecase) expireBullets(ctx context.Context, roomID string) (int, error) {
 	room, err := uc.roomRepo.GetByID(ctx, roomID)
 	if err != nil {
 		if errors.Is(err, apperr.ErrNotFound) {
-			return nil, nil, nil, apperr.ErrRoomNotFound
+			return 0, apperr.ErrRoomNotFound
 		}
-		return nil, nil, nil, err
+		return 0, err
 	}
 
-	if room.FishMap == nil {
-		return nil, nil, nil, apperr.ErrFishNotFound
+	now := uc.now()
+	expired := room.ExpireBullets(now.UnixMilli())
+	if len(expired) == 0 {
+		return 0, nil
 	}
+	events := newEventBatch(room, now)
+	events.settled(expired)
+	room.NextSeq()
 
-	fish, ok := room.FishMap[fishUID]
-	if !ok {
-		return nil, nil, nil, apperr.ErrFishNotFound
+	if err := uc.roomRepo.Save(ctx, room); err != nil {
+		return 0, err
+	}
+	if err := uc.saveRefundedPlayers(ctx, room, expired, ""); err != nil {
+		return 0, err
 	}
-	if !fish.Alive || fish.HP <= 0 {
-		return nil, nil, nil, apperr.ErrFishAlreadyDead
+	events.flush(ctx, uc.events, uc.publisher)
+	if err := recordRTP(ctx, uc.rtpRepo, roomID, -refundTotal(room, expired), 0); err != nil {
+		return 0, err
 	}
+	logger.FromContext(ctx).Info("Expired bullets settled",
+		zap.Int("bullets", len(expired)),
+		zap.Int64("refunded", refundTotal(room, expired)),
+	)
+	return len(expired), nil
+}
 
-	player, ok := room.Players[playerID]
-	if !ok {
-		p, err := uc.playerRepo.GetByID(ctx, playerID)
-		if err != nil {
-			if errors.Is(err, apperr.ErrNotFound) {
-				return nil, nil, nil, apperr.ErrPlayerNotFound
-			}
-			return nil, nil, nil, err
+// fire charges for a bullet. Once the room is saved it returns the result
+// along with any error, because from then on the charge stands.
+func (uc *ShootUsecase) fire(ctx context.Context, roomID, playerID, bulletID string) (*entity.ShotResult, error) {
+	room, err := uc.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrRoomNotFound
 		}
-		player = p
+		return nil, err
 	}
 
-	if player.RoomID != roomID {
-		return nil, nil, nil, apperr.ErrPlayerNotInRoom
+	player, ok := room.Players[playerID]
+	if !ok || player.RoomID != roomID {
+		return nil, apperr.ErrPlayerNotInRoom
 	}
 	if player.Balance < 0 {
-		return nil, nil, nil, apperr.ErrInvalidBalance
+		return nil, apperr.ErrInvalidBalance
 	}
 
-	gun, err := uc.gunRepo.GetByID(ctx, player.GunID)
+	now := uc.now()
+	expired := room.ExpireBullets(now.UnixMilli())
+	events := newEventBatch(room, now)
+	events.settled(expired)
+
+	if room.LiveBulletCount(playerID) >= room.Config.LiveBulletCap() {
+		return nil, apperr.ErrTooManyBullets
+	}
+	if _, exists := room.Bullets[entity.BulletKey(playerID, bulletID)]; exists {
+		return nil, apperr.ErrBulletIDConflict
+	}
+
+	gun, err := uc.gunFor(ctx, room, player.GunID)
 	if err != nil {
-		if errors.Is(err, apperr.ErrNotFound) {
-			return nil, nil, nil, apperr.ErrGunNotFound
-		}
-		return nil, nil, nil, err
+		return nil, err
 	}
 
 	if player.Balance < int64(gun.BulletCost) {
-		return nil, nil, nil, apperr.ErrInsufficientBalance
+		return nil, apperr.ErrInsufficientBalance
 	}
 
 	player.Balance -= int64(gun.BulletCost)
 
+	bullet := &entity.Bullet{
+		BulletID:  bulletID,
+		PlayerID:  playerID,
+		GunID:     gun.GunID,
+		Cost:      int64(gun.BulletCost),
+		Damage:    gun.Damage,
+		FiredAt:   now.UnixMilli(),
+		ExpiresAt: now.UnixMilli() + room.Config.BulletTTL(),
+	}
+	if room.Bullets == nil {
+		room.Bullets = map[string]*entity.Bullet{}
+	}
+	room.Bullets[entity.BulletKey(playerID, bulletID)] = bullet
+	room.NextSeq()
+	events.add(&entity.GameEvent{Type: entity.EventShotFired, PlayerID: playerID, Bullet: bullet})
+	events.balance(player, -bullet.Cost, entity.BalanceReasonBet)
+
+	result := &entity.ShotResult{
+		Shot: entity.Shot{
+			BulletID: bulletID,
+			PlayerID: playerID,
+			GunID:    gun.GunID,
+			FireTime: now.Unix(),
+		},
+		RoomID: roomID,
+		Bullet: bullet,
+		Player: player,
+		Cost:   bullet.Cost,
+	}
+
+	if err := uc.roomRepo.Save(ctx, room); err != nil {
+		return nil, err
+	}
+	uc.metrics.ShotFired(room.Config.GameName, bullet.GunID, bullet.Cost)
+	if err := uc.commitShot(ctx, bulletID, result, bullet.Cost-refundTotal(room, expired), 0); err != nil {
+		return result, err
+	}
+	if err := uc.playerRepo.Save(ctx, player); err != nil {
+		return result, err
+	}
+	if err := uc.saveRefundedPlayers(ctx, room, expired, playerID); err != nil {
+		return result, err
+	}
+	events.flush(ctx, uc.events, uc.publisher)
+	return result, nil
+}
+
+// hit resolves a bullet. Like fire, it returns the result along with any
+// error once the room is saved.
+func (uc *ShootUsecase) hit(ctx context.Context, roomID, playerID, bulletID, fishUID string) (*entity.ShotResult, error) {
+	room, err := uc.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrRoomNotFound
+		}
+		return nil, err
+	}
+
+	key := entity.BulletKey(playerID, bulletID)
+	bullet, ok := room.Bullets[key]
+	if !ok {
+		return nil, apperr.ErrBulletNotFound
+	}
+
+	now := uc.now()
+	nowMs := now.UnixMilli()
+	expired := room.ExpireBullets(nowMs)
+	events := newEventBatch(room, now)
+	events.settled(expired)
+	if bullet.IsExpired(nowMs) {
+		// Persist the settlement so the expired bullet is not reported twice.
+		room.NextSeq()
+		if err := uc.roomRepo.Save(ctx, room); err != nil {
+			return nil, err
+		}
+		if err := uc.saveRefundedPlayers(ctx, room, expired, ""); err != nil {
+			return nil, err
+		}
+		events.flush(ctx, uc.events, uc.publisher)
+		if err := recordRTP(ctx, uc.rtpRepo, roomID, -refundTotal(room, expired), 0); err != nil {
+			return nil, err
+		}
+		return nil, apperr.ErrBulletExpired
+	}
+	delete(room.Bullets, key)
+
+	player, ok := room.Players[playerID]
+	if !ok {
+		return nil, apperr.ErrPlayerNotInRoom
+	}
+
 	reward := int64(0)
-	fish.HP -= gun.Damage
-	if fish.HP <= 0 {
-		fish.Alive = false
-		fishType, err := uc.fishRepo.GetTypeByID(ctx, fish.FishID)
+	hit := false
+	var draw *entity.RNGDraw
+	var fairShot *entity.FairShot
+	fish, ok := room.FishMap[fishUID]
+	if ok && fish.IsAlive() {
+		in, err := uc.hitInput(ctx, room, bullet, fish)
+		if err != nil {
+			return nil, err
+		}
+
+		roller, session, err := uc.hitRoller(ctx, room, player)
 		if err != nil {
-			if errors.Is(err, apperr.ErrNotFound) {
-				return nil, nil, nil, apperr.ErrFishTypeNotFound
+			return nil, err
+		}
+		outcome := gameBaseSevices.ResolveHit(roller, in)
+		hit = outcome.Hit
+		draw = roller.draw
+		if session != nil {
+			fairShot = &entity.FairShot{
+				SessionID: session.SessionID,
+				Nonce:     draw.Index,
+				RoomID:    roomID,
+				PlayerID:  playerID,
+				BulletID:  bulletID,
+				FishUID:   fishUID,
+				HitRate:   in.HitRate,
+				Damage:    in.Damage,
+				HP:        in.HP,
+				Payout:    in.Payout,
+				Value:     draw.Value,
+				Hit:       outcome.Hit,
+				Killed:    outcome.Killed,
+				Reward:    outcome.Payout,
+				CreatedAt: uc.now().Unix(),
 			}
-			return nil, nil, nil, err
 		}
-		reward = int64(fishType.Reward)
+		if outcome.Hit {
+			fish.TakeDamage(outcome.Damage)
+		}
+		reward = outcome.Payout
 		player.Balance += reward
 	}
+	room.NextSeq()
+
+	hitEvent := &entity.GameEvent{
+		Type:     entity.EventShotHit,
+		PlayerID: playerID,
+		Bullet:   bullet,
+		FishUID:  fishUID,
+		Hit:      hit,
+	}
+	if hit {
+		hitEvent.Damage = bullet.Damage
+	}
+	if ok {
+		snapshot := *fish
+		hitEvent.Fish = &snapshot
+	}
+	events.add(hitEvent)
+	killed := ok && hit && fish.IsDead()
+	if killed {
+		snapshot := *fish
+		events.add(&entity.GameEvent{
+			Type:     entity.EventFishKilled,
+			PlayerID: playerID,
+			FishUID:  fishUID,
+			Fish:     &snapshot,
+			Amount:   reward,
+		})
+	}
+	events.balance(player, reward, entity.BalanceReasonWin)
+
+	result := &entity.ShotResult{
+		Shot: entity.Shot{
+			BulletID: bulletID,
+			PlayerID: playerID,
+			FishUID:  fishUID,
+			GunID:    bullet.GunID,
+			FireTime: bullet.FiredAt / 1000,
+		},
+		RoomID: roomID,
+		Bullet: bullet,
+		Fish:   fish,
+		Player: player,
+		Cost:   bullet.Cost,
+		Hit:    hit,
+		Reward: reward,
+		Draw:   draw,
+	}
 
 	if err := uc.roomRepo.Save(ctx, room); err != nil {
-		return nil, nil, nil, err
+		return nil, err
+	}
+	uc.metrics.ShotResolved(room.Config.GameName, hit, killed, reward)
+	if err := uc.commitShot(ctx, bulletID+":hit", result, -refundTotal(room, expired), reward); err != nil {
+		return result, err
 	}
 	if err := uc.playerRepo.Save(ctx, player); err != nil {
-		return nil, nil, nil, err
+		return result, err
+	}
+	if err := uc.saveRefundedPlayers(ctx, room, expired, playerID); err != nil {
+		return result, err
 	}
+	if fairShot != nil {
+		if err := uc.fairShotRepo.Save(ctx, fairShot); err != nil {
+			return result, err
+		}
+	}
+	events.flush(ctx, uc.events, uc.publisher)
+	return result, nil
+}
 
-	if uc.rtpRepo != nil {
-		state, err := uc.rtpRepo.GetByRoomID(ctx, roomID)
-		if err != nil && !errors.Is(err, apperr.ErrNotFound) {
-			return nil, nil, nil, err
+// gunFor returns the gun a player fires with. In game rooms its cost and
+// damage come from the bullet config version the room is pinned to.
+func (uc *ShootUsecase) gunFor(ctx context.Context, room *entity.Room, gunID int) (*entity.Gun, error) {
+	if room.Config.GameName != "" {
+		rules, err := uc.configs.rules(ctx, room)
+		if err != nil {
+			return nil, err
 		}
-		if state == nil {
-			state = &entity.RTPState{}
+		b, err := rules.bullet(gunID)
+		if err != nil {
+			return nil, err
 		}
-		state.TotalBet += int64(gun.BulletCost)
-		state.TotalWin += reward
-		if err := uc.rtpRepo.Save(ctx, roomID, state); err != nil {
-			return nil, nil, nil, err
+		return &entity.Gun{GunID: b.BulletID, BulletCost: b.Cost, Damage: b.Damage}, nil
+	}
+
+	gun, err := uc.gunRepo.GetByID(ctx, gunID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrGunNotFound
 		}
+		return nil, err
 	}
+	return gun, nil
+}
 
-	shot := &entity.Shot{
-		BulletID: fmt.Sprintf("%s-%d", playerID, uc.now().UnixNano()),
-		PlayerID: playerID,
-		FishUID:  fishUID,
-		GunID:    gun.GunID,
-		FireTime: uc.now().Unix(),
+// hitInput describes bullet reaching fish. Game rooms derive it from the
+// config versions they are pinned to through gameBaseSevices.HitInputFor,
+// as the simulator does, pricing the bullet at what it was charged; other
+// rooms use the fish type catalogue.
+func (uc *ShootUsecase) hitInput(ctx context.Context, room *entity.Room, bullet *entity.Bullet, fish *entity.FishInstance) (gameBaseModels.HitInput, error) {
+	if room.Config.GameName != "" {
+		rules, err := uc.configs.rules(ctx, room)
+		if err != nil {
+			return gameBaseModels.HitInput{}, err
+		}
+		charged := &gameBaseModels.BulletInfo{BulletID: bullet.GunID, Cost: int(bullet.Cost), Damage: bullet.Damage}
+		return rules.hitInput(charged, fish.FishID, fish.HP)
 	}
 
-	return shot, fish, player, nil
+	fishType, err := uc.fishRepo.GetTypeByID(ctx, fish.FishID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return gameBaseModels.HitInput{}, apperr.ErrFishTypeNotFound
+		}
+		return gameBaseModels.HitInput{}, err
+	}
+	return gameBaseModels.HitInput{
+		HitRate: fishType.EffectiveHitRate(),
+		Damage:  bullet.Damage,
+		HP:      fish.HP,
+		Payout:  gameBaseSevices.Payout(fishType.Reward, 1),
+	}, nil
+}
+
+// logHit logs the resolution of a shot. Kills move credits, so they are
+// always written; plain hits and misses are sampled like other shot logs.
+func (uc *ShootUsecase) logHit(ctx context.Context, result *entity.ShotResult) {
+	fields := []zap.Field{
+		zap.String("bullet_id", result.Shot.BulletID),
+		zap.String("fish_uid", result.Shot.FishUID),
+		zap.Bool("hit", result.Hit),
+	}
+	if result.Hit && result.Fish != nil && result.Fish.IsDead() {
+		logger.FromContext(ctx).Info("Fish killed", append(fields,
+			zap.Int("fish_id", result.Fish.FishID),
+			zap.Int64("cost", result.Cost),
+			zap.Int64("payout", result.Reward),
+			zap.Int64("balance", result.Player.Balance),
+		)...)
+		return
+	}
+	uc.logShot(ctx, zapcore.DebugLevel, "Shot resolved", fields...)
+}
+
+// logShot writes a shot log line if the shot sampler lets it through.
+func (uc *ShootUsecase) logShot(ctx context.Context, lvl zapcore.Level, msg string, fields ...zap.Field) {
+	if uc.shotLogs.Allow(msg) {
+		logger.FromContext(ctx).Log(lvl, msg, fields...)
+	}
+}
+
+// logShotFailure logs a failed shot. Rejections are sampled, since a client
+// firing without balance can produce them at its fire rate; unexpected
+// errors are always written.
+func (uc *ShootUsecase) logShotFailure(ctx context.Context, msg string, err error, fields ...zap.Field) {
+	if failureLevel(err) == zapcore.InfoLevel && !uc.shotLogs.Allow(msg) {
+		return
+	}
+	logFailure(ctx, msg, err, fields...)
+}
+
+// commitShot completes the dedupe claim of a shot whose room write has
+// landed, so a retry after any later failure replays result rather than
+// being rejected, and books the shot for RTP. A failed completion leaves
+// the claim pending until it expires; it is never released.
+func (uc *ShootUsecase) commitShot(ctx context.Context, claimID string, result *entity.ShotResult, bet, win int64) error {
+	err := uc.shotResultRepo.Complete(ctx, result.Shot.PlayerID, claimID, result, shotDedupeTTL)
+	if rtpErr := recordRTP(ctx, uc.rtpRepo, result.RoomID, bet, win); err == nil {
+		err = rtpErr
+	}
+	return err
+}
+
+// saveRefundedPlayers persists players credited by expired bullet refunds.
+// skipPlayerID is already being saved by the caller.
+func (uc *ShootUsecase) saveRefundedPlayers(ctx context.Context, room *entity.Room, expired []*entity.Bullet, skipPlayerID string) error {
+	if !room.Config.RefundsExpiredBullets() {
+		return nil
+	}
+	saved := map[string]bool{skipPlayerID: true}
+	for _, b := range expired {
+		if saved[b.PlayerID] {
+			continue
+		}
+		saved[b.PlayerID] = true
+		if p, ok := room.Players[b.PlayerID]; ok {
+			if err := uc.playerRepo.Save(ctx, p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recordingRoller feeds ResolveHit from a room stream and keeps the draw it
+// consumed so the outcome can be audited.
+type recordingRoller struct {
+	stream port.RandomStream
+	draw   *entity.RNGDraw
+}
+
+func (r *recordingRoller) Float64() float64 {
+	value, index := r.stream.Draw()
+	r.draw = &entity.RNGDraw{SeedID: r.stream.SeedID(), Index: index, Value: value}
+	return value
+}
+
+// hitRoller picks the source of a hit roll. Provably-fair rooms draw from
+// the player's committed session at the seat's next nonce, which is taken
+// off the loaded room so it is only spent if the room save succeeds; other
+// rooms use the room RNG stream.
+func (uc *ShootUsecase) hitRoller(ctx context.Context, room *entity.Room, player *entity.Player) (*recordingRoller, *entity.FairSession, error) {
+	if !room.Config.ProvablyFair {
+		return &recordingRoller{stream: uc.rng.Stream(room.RoomID)}, nil, nil
+	}
+
+	session, err := uc.fairSessionRepo.GetActive(ctx, room.RoomID, player.PlayerID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, nil, apperr.ErrFairSessionNotFound
+		}
+		return nil, nil, err
+	}
+	nonce := player.FairNonce
+	player.FairNonce++
+	return &recordingRoller{stream: &fairStream{session: session, nonce: nonce}}, session, nil
+}
+
+func refundTotal(room *entity.Room, expired []*entity.Bullet) int64 {
+	if !room.Config.RefundsExpiredBullets() {
+		return 0
+	}
+	total := int64(0)
+	for _, b := range expired {
+		total += b.Cost
+	}
+	return total
+}
+
+// recordRTP adds a room's bet and win to its running RTP totals. Refunds
+// are booked as negative bets.
+func recordRTP(ctx context.Context, repo port.RTPRepository, roomID string, bet, win int64) error {
+	if repo == nil || (bet == 0 && win == 0) {
+		return nil
+	}
+	state, err := repo.GetByRoomID(ctx, roomID)
+	if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+		return err
+	}
+	if state == nil {
+		state = &entity.RTPState{}
+	}
+	state.TotalBet += bet
+	state.TotalWin += win
+	return repo.Save(ctx, roomID, state)
 }
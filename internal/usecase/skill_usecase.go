@@ -7,22 +7,42 @@ import (
 
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
 	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
 	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
 )
 
 type SkillUsecase struct {
+	roomRepo   port.RoomRepository
 	playerRepo port.PlayerRepository
+	events     port.EventStore
+	publisher  port.RoomPublisher
 	now        func() time.Time
 }
 
-func NewSkillUsecase(playerRepo port.PlayerRepository) *SkillUsecase {
+func NewSkillUsecase(roomRepo port.RoomRepository, playerRepo port.PlayerRepository, events port.EventStore, opts ...Option) *SkillUsecase {
+	o := newOptions(opts)
 	return &SkillUsecase{
+		roomRepo:   roomRepo,
 		playerRepo: playerRepo,
-		now:        time.Now,
+		events:     events,
+		publisher:  o.publisher,
+		now:        o.now,
 	}
 }
 
-func (uc *SkillUsecase) UseSkill(ctx context.Context, playerID string, skill *entity.Skill) error {
+// UseSkill charges a skill to the player and starts its cooldown. A seated
+// player's balance and cooldowns live on their seat in the room, as Fire and
+// Hit charge them there, so the skill is applied to the seat and the player
+// record follows; a player who is not seated only has the record.
+func (uc *SkillUsecase) UseSkill(ctx context.Context, playerID string, skill *entity.Skill) (err error) {
+	ctx = logContext(ctx, "", playerID)
+	defer func() {
+		if err != nil {
+			logFailure(ctx, "Skill rejected", err, zap.String("skill_type", skill.SkillType))
+		}
+	}()
+
 	if playerID == "" {
 		return apperr.ErrInvalidPlayerID
 	}
@@ -39,20 +59,85 @@ func (uc *SkillUsecase) UseSkill(ctx context.Context, playerID string, skill *en
 		}
 		return err
 	}
+	if roomID := player.RoomID; roomID != "" {
+		err = retryRoomConflict(ctx, func() (err error) {
+			player, err = uc.useSeatedSkill(ctx, roomID, playerID, skill)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		if err := uc.spend(player, skill); err != nil {
+			return err
+		}
+		if err := uc.playerRepo.Save(ctx, player); err != nil {
+			return err
+		}
+	}
 
-	if !player.CanSpend(int64(skill.Cost)) {
-		return apperr.ErrInsufficientBalance
+	logger.FromContext(ctx).Info("Skill used",
+		zap.String("room_id", player.RoomID),
+		zap.String("skill_type", skill.SkillType),
+		zap.Int("cost", skill.Cost),
+		zap.Int64("balance", player.Balance),
+	)
+	return nil
+}
+
+// useSeatedSkill is one attempt of UseSkill for a player seated in roomID.
+func (uc *SkillUsecase) useSeatedSkill(ctx context.Context, roomID, playerID string, skill *entity.Skill) (*entity.Player, error) {
+	room, err := uc.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrRoomNotFound
+		}
+		return nil, err
+	}
+	player, ok := room.Players[playerID]
+	if !ok {
+		return nil, apperr.ErrPlayerNotInRoom
 	}
+	if err := uc.spend(player, skill); err != nil {
+		return nil, err
+	}
+	room.NextSeq()
 
-	if err := player.Spend(int64(skill.Cost)); err != nil {
-		return err
+	if err := uc.roomRepo.Save(ctx, room); err != nil {
+		return nil, err
+	}
+	if err := uc.playerRepo.Save(ctx, player); err != nil {
+		return nil, err
 	}
 
-	player.LastActionAt = uc.now().Unix()
+	events := newEventBatch(room, uc.now())
+	events.add(&entity.GameEvent{
+		Type:      entity.EventSkillUsed,
+		PlayerID:  playerID,
+		SkillType: skill.SkillType,
+	})
+	events.balance(player, -int64(skill.Cost), entity.BalanceReasonSkill)
+	events.flush(ctx, uc.events, uc.publisher)
+	return player, nil
+}
 
-	if err := uc.playerRepo.Save(ctx, player); err != nil {
+// spend charges the skill and starts its cooldown if it is ready and
+// affordable.
+func (uc *SkillUsecase) spend(player *entity.Player, skill *entity.Skill) error {
+	now := uc.now()
+	if cd := player.Cooldown(skill.SkillType); cd != nil && !cd.IsReady(now, skill) {
+		return apperr.ErrSkillOnCooldown
+	}
+
+	if !player.CanSpend(int64(skill.Cost)) {
+		return apperr.ErrInsufficientBalance
+	}
+
+	if err := player.Spend(int64(skill.Cost)); err != nil {
 		return err
 	}
 
+	player.StartCooldown(now, skill)
+	player.LastActionAt = now.Unix()
 	return nil
 }
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/port"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+)
+
+type SyncUsecase struct {
+	roomRepo port.RoomRepository
+	gunRepo  port.GunRepository
+	now      func() time.Time
+}
+
+func NewSyncUsecase(roomRepo port.RoomRepository, gunRepo port.GunRepository, opts ...Option) *SyncUsecase {
+	o := newOptions(opts)
+	return &SyncUsecase{
+		roomRepo: roomRepo,
+		gunRepo:  gunRepo,
+		now:      o.now,
+	}
+}
+
+// Snapshot builds the state a reconnecting client needs to resume play:
+// alive fish with their elapsed path time, seated players and their guns,
+// and the caller's own balance and skill cooldowns. Everything is read from
+// the room document, whose seats carry the balance and cooldowns that shots
+// and skills charge, so it all agrees with Seq.
+func (uc *SyncUsecase) Snapshot(ctx context.Context, roomID, playerID string) (*entity.RoomSnapshot, error) {
+	if roomID == "" {
+		return nil, apperr.ErrInvalidRoomID
+	}
+	if playerID == "" {
+		return nil, apperr.ErrInvalidPlayerID
+	}
+
+	room, err := uc.roomRepo.GetByID(ctx, roomID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, apperr.ErrRoomNotFound
+		}
+		return nil, err
+	}
+
+	seated, ok := room.Players[playerID]
+	if !ok {
+		return nil, apperr.ErrPlayerNotInRoom
+	}
+
+	now := uc.now()
+	snapshot := &entity.RoomSnapshot{
+		RoomID:     room.RoomID,
+		Seq:        room.Seq,
+		ServerTime: now.UnixMilli(),
+		Fish:       []entity.FishSnapshot{},
+		Seats:      []entity.SeatSnapshot{},
+	}
+
+	for _, fish := range room.FishMap {
+		if !fish.IsAlive() {
+			continue
+		}
+		elapsed := now.UnixMilli() - fish.SpawnTime*1000
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		snapshot.Fish = append(snapshot.Fish, entity.FishSnapshot{
+			FishInstance: *fish,
+			ElapsedMs:    elapsed,
+		})
+	}
+	sort.Slice(snapshot.Fish, func(i, j int) bool {
+		return snapshot.Fish[i].FishUID < snapshot.Fish[j].FishUID
+	})
+
+	guns := map[int]*entity.Gun{}
+	for _, p := range room.Players {
+		seat := entity.SeatSnapshot{
+			PlayerID: p.PlayerID,
+			SeatID:   p.SeatID,
+			IsOnline: p.IsOnline,
+		}
+		if p.GunID > 0 {
+			gun, cached := guns[p.GunID]
+			if !cached {
+				gun, err = uc.gunRepo.GetByID(ctx, p.GunID)
+				if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+					return nil, err
+				}
+				guns[p.GunID] = gun
+			}
+			seat.Gun = gun
+		}
+		snapshot.Seats = append(snapshot.Seats, seat)
+	}
+	sort.Slice(snapshot.Seats, func(i, j int) bool {
+		return snapshot.Seats[i].SeatID < snapshot.Seats[j].SeatID
+	})
+
+	self := &entity.PlayerSnapshot{
+		PlayerID:  seated.PlayerID,
+		SeatID:    seated.SeatID,
+		Balance:   seated.Balance,
+		GunID:     seated.GunID,
+		Cooldowns: []entity.CooldownSnapshot{},
+	}
+	for i := range seated.SkillCooldowns {
+		cd := &seated.SkillCooldowns[i]
+		self.Cooldowns = append(self.Cooldowns, entity.CooldownSnapshot{
+			SkillType:   cd.SkillType,
+			ReadyAt:     cd.ReadyAt,
+			RemainingMs: cd.RemainingMs(now),
+		})
+	}
+	snapshot.Self = self
+
+	return snapshot, nil
+}
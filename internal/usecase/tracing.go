@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/BT2701/backend-fishing-gameplay/internal/usecase")
+
+// startSpan starts a span for a usecase call. Pair it with endSpan:
+//
+//	ctx, span := startSpan(ctx, "ShootUsecase.Fire", ...)
+//	defer endSpan(span, &err)
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan ends span with the outcome in *errp. Domain errors such as an
+// insufficient balance are the usecase working as intended, so only their
+// code is recorded; anything else marks the span as failed.
+func endSpan(span trace.Span, errp *error) {
+	if err := *errp; err != nil {
+		if code := apperr.CodeOf(err); code != "" {
+			span.SetAttributes(attribute.String("error.code", string(code)))
+		} else {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+func roomAttr(roomID string) attribute.KeyValue {
+	return attribute.String("room_id", roomID)
+}
+
+func playerAttr(playerID string) attribute.KeyValue {
+	return attribute.String("player_id", playerID)
+}
+
+func gameAttr(gameName string) attribute.KeyValue {
+	return attribute.String("game_name", gameName)
+}
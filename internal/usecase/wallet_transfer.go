@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/BT2701/backend-fishing-gameplay/internal/domain/entity"
+	"github.com/BT2701/backend-fishing-gameplay/internal/infrastructure/logger"
+	apperr "github.com/BT2701/backend-fishing-gameplay/pkg/error"
+	"go.uber.org/zap"
+)
+
+const (
+	walletMaxAttempts = 3
+	walletRetryDelay  = 200 * time.Millisecond
+
+	// walletReconcileAfter keeps reconciliation away from transfers that may
+	// still be in flight on another request.
+	walletReconcileAfter = 30 * time.Second
+	walletReconcileBatch = 100
+)
+
+func (uc *RoomUsecase) newTransfer(kind, roomID, playerID string, amount int64) *entity.WalletTransfer {
+	now := uc.now()
+	return &entity.WalletTransfer{
+		TxID:      fmt.Sprintf("%s:%s:%s:%d", kind, roomID, playerID, now.UnixNano()),
+		PlayerID:  playerID,
+		RoomID:    roomID,
+		Kind:      kind,
+		Amount:    amount,
+		Status:    entity.WalletTransferPending,
+		CreatedAt: now.Unix(),
+		UpdatedAt: now.Unix(),
+	}
+}
+
+// buyIn debits the wallet and credits the player's game balance in memory.
+// The caller persists the player and then commits or rolls back the transfer.
+func (uc *RoomUsecase) buyIn(ctx context.Context, roomID string, player *entity.Player, amount int64) (*entity.WalletTransfer, error) {
+	transfer := uc.newTransfer(entity.WalletTransferBuyIn, roomID, player.PlayerID, amount)
+	if err := uc.transferRepo.Save(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	err := uc.withWalletRetry(ctx, transfer, func() error {
+		_, err := uc.wallet.Debit(ctx, player.PlayerID, transfer.TxID, amount)
+		return err
+	})
+	if err != nil {
+		if isDefinitiveWalletError(err) {
+			uc.finishTransfer(ctx, transfer, entity.WalletTransferFailed)
+			return nil, err
+		}
+		// The debit may or may not have landed; leave the transfer pending so
+		// reconciliation rolls it back.
+		uc.leavePending(ctx, transfer, err)
+		return nil, apperr.ErrWalletUnavailable
+	}
+
+	player.Balance += amount
+	player.MarkWalletTxApplied(transfer.TxID)
+	return transfer, nil
+}
+
+func (uc *RoomUsecase) rollbackBuyIn(ctx context.Context, transfer *entity.WalletTransfer) {
+	if transfer == nil {
+		return
+	}
+	err := uc.withWalletRetry(ctx, transfer, func() error {
+		return uc.wallet.Rollback(ctx, transfer.PlayerID, transfer.TxID)
+	})
+	// An unknown transaction means the debit never landed.
+	if err != nil && !errors.Is(err, apperr.ErrWalletTxNotFound) {
+		uc.leavePending(ctx, transfer, err)
+		return
+	}
+	uc.finishTransfer(ctx, transfer, entity.WalletTransferRolledBack)
+}
+
+func (uc *RoomUsecase) cashOut(ctx context.Context, transfer *entity.WalletTransfer) error {
+	err := uc.withWalletRetry(ctx, transfer, func() error {
+		_, err := uc.wallet.Credit(ctx, transfer.PlayerID, transfer.TxID, transfer.Amount)
+		return err
+	})
+	if err != nil {
+		uc.leavePending(ctx, transfer, err)
+		return err
+	}
+	uc.finishTransfer(ctx, transfer, entity.WalletTransferCommitted)
+	return nil
+}
+
+func (uc *RoomUsecase) finishTransfer(ctx context.Context, transfer *entity.WalletTransfer, status string) {
+	transfer.Status = status
+	transfer.UpdatedAt = uc.now().Unix()
+	_ = uc.transferRepo.Save(ctx, transfer)
+
+	log := logger.FromContext(ctx).With(transferFields(transfer)...)
+	if status == entity.WalletTransferCommitted {
+		log.Info("Wallet transfer committed")
+	} else {
+		log.Warn("Wallet transfer not applied", zap.String("status", status), zap.String("last_error", transfer.LastError))
+	}
+}
+
+// leavePending saves a transfer whose outcome is unknown so reconciliation
+// settles it later.
+func (uc *RoomUsecase) leavePending(ctx context.Context, transfer *entity.WalletTransfer, err error) {
+	_ = uc.transferRepo.Save(ctx, transfer)
+	logger.FromContext(ctx).Warn("Wallet transfer left pending for reconciliation",
+		append(transferFields(transfer), zap.Error(err))...)
+}
+
+func transferFields(transfer *entity.WalletTransfer) []zap.Field {
+	return []zap.Field{
+		zap.String("tx_id", transfer.TxID),
+		zap.String("kind", transfer.Kind),
+		zap.Int64("amount", transfer.Amount),
+		zap.Int("attempts", transfer.Attempts),
+	}
+}
+
+// ReconcileWalletTransfers settles transfers left pending by wallet outages
+// or crashes. A buy-in is committed if its credit reached the player record
+// and rolled back otherwise; a cash-out is re-credited if the player's game
+// balance was zeroed and dropped otherwise. It returns the number of
+// transfers settled.
+func (uc *RoomUsecase) ReconcileWalletTransfers(ctx context.Context) (_ int, err error) {
+	ctx, span := startSpan(ctx, "RoomUsecase.ReconcileWalletTransfers")
+	defer endSpan(span, &err)
+
+	cutoff := uc.now().Add(-walletReconcileAfter).Unix()
+	pending, err := uc.transferRepo.ListPending(ctx, cutoff, walletReconcileBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, transfer := range pending {
+		ctx := logContext(ctx, transfer.RoomID, transfer.PlayerID)
+		player, err := uc.playerRepo.GetByID(ctx, transfer.PlayerID)
+		if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+			return settled, err
+		}
+		applied := player != nil && player.HasAppliedWalletTx(transfer.TxID)
+
+		switch transfer.Kind {
+		case entity.WalletTransferBuyIn:
+			if applied {
+				uc.finishTransfer(ctx, transfer, entity.WalletTransferCommitted)
+			} else {
+				uc.rollbackBuyIn(ctx, transfer)
+			}
+		case entity.WalletTransferCashOut:
+			if !applied {
+				uc.finishTransfer(ctx, transfer, entity.WalletTransferFailed)
+			} else if err := uc.cashOut(ctx, transfer); err != nil {
+				continue
+			}
+		default:
+			continue
+		}
+		if transfer.Status != entity.WalletTransferPending {
+			settled++
+		}
+	}
+	return settled, nil
+}
+
+// withWalletRetry retries transient wallet failures with linear backoff.
+// Definitive apperr rejections are returned immediately.
+func (uc *RoomUsecase) withWalletRetry(ctx context.Context, transfer *entity.WalletTransfer, fn func() error) error {
+	var lastErr error
+	for attempt := 1; attempt <= walletMaxAttempts; attempt++ {
+		transfer.Attempts++
+		transfer.UpdatedAt = uc.now().Unix()
+		lastErr = fn()
+		if lastErr == nil {
+			transfer.LastError = ""
+			return nil
+		}
+		transfer.LastError = lastErr.Error()
+		if isDefinitiveWalletError(lastErr) || ctx.Err() != nil {
+			return lastErr
+		}
+		if attempt < walletMaxAttempts {
+			uc.sleep(walletRetryDelay * time.Duration(attempt))
+		}
+	}
+	return lastErr
+}
+
+func isDefinitiveWalletError(err error) bool {
+	return apperr.CodeOf(err) != ""
+}
@@ -41,6 +41,7 @@ const (
 	CodeRoomAlreadyExists     Code = "ROOM_ALREADY_EXISTS"
 	CodeRoomNotFound          Code = "ROOM_NOT_FOUND"
 	CodeRoomFull              Code = "ROOM_FULL"
+	CodeRoomConflict          Code = "ROOM_CONFLICT"
 	CodeSeatTaken             Code = "SEAT_TAKEN"
 	CodeInvalidBalance        Code = "INVALID_BALANCE"
 	CodeInvalidMaxPlayers     Code = "INVALID_MAX_PLAYERS"
@@ -66,6 +67,30 @@ const (
 	CodeGamePathsNotFound     Code = "GAME_PATHS_NOT_FOUND"
 	CodeGameRTPNotFound       Code = "GAME_RTP_NOT_FOUND"
 	CodeGameFishTypesNotFound Code = "GAME_FISH_TYPES_NOT_FOUND"
+	CodeSkillOnCooldown       Code = "SKILL_ON_COOLDOWN"
+	CodeUnauthorized          Code = "UNAUTHORIZED"
+	CodeInvalidToken          Code = "INVALID_TOKEN"
+	CodeForbidden             Code = "FORBIDDEN"
+	CodeInvalidBuyIn          Code = "INVALID_BUY_IN"
+	CodeWalletUnavailable     Code = "WALLET_UNAVAILABLE"
+	CodeWalletTxNotFound      Code = "WALLET_TX_NOT_FOUND"
+	CodeWalletTxRolledBack    Code = "WALLET_TX_ROLLED_BACK"
+	CodeInvalidBulletID       Code = "INVALID_BULLET_ID"
+	CodeShotInProgress        Code = "SHOT_IN_PROGRESS"
+	CodeBulletIDConflict      Code = "BULLET_ID_CONFLICT"
+	CodeBulletNotFound        Code = "BULLET_NOT_FOUND"
+	CodeBulletExpired         Code = "BULLET_EXPIRED"
+	CodeTooManyBullets        Code = "TOO_MANY_BULLETS"
+	CodeFairSessionNotFound   Code = "FAIR_SESSION_NOT_FOUND"
+	CodeFairShotNotFound      Code = "FAIR_SHOT_NOT_FOUND"
+	CodeSeedNotRevealed       Code = "SEED_NOT_REVEALED"
+	CodeInvalidClientSeed     Code = "INVALID_CLIENT_SEED"
+	CodeInvalidGameConfig     Code = "INVALID_GAME_CONFIG"
+	CodeUnknownConfigKind     Code = "UNKNOWN_CONFIG_KIND"
+	CodeConfigVersionConflict Code = "CONFIG_VERSION_CONFLICT"
+	CodeConfigVersionNotFound Code = "CONFIG_VERSION_NOT_FOUND"
+	CodeInvalidRolloutPercent Code = "INVALID_ROLLOUT_PERCENT"
+	CodeShuttingDown          Code = "SHUTTING_DOWN"
 )
 
 var (
@@ -73,6 +98,7 @@ var (
 	ErrRoomAlreadyExists     = New(CodeRoomAlreadyExists, "room already exists")
 	ErrRoomNotFound          = New(CodeRoomNotFound, "room not found")
 	ErrRoomFull              = New(CodeRoomFull, "room is full")
+	ErrRoomConflict          = New(CodeRoomConflict, "room was changed by another request; retry")
 	ErrSeatTaken             = New(CodeSeatTaken, "seat is taken")
 	ErrInvalidBalance        = New(CodeInvalidBalance, "balance must be >= 0")
 	ErrInvalidMaxPlayers     = New(CodeInvalidMaxPlayers, "max players must be > 0")
@@ -98,4 +124,28 @@ var (
 	ErrGamePathsNotFound     = New(CodeGamePathsNotFound, "game paths not found")
 	ErrGameRTPNotFound       = New(CodeGameRTPNotFound, "game rtp not found")
 	ErrGameFishTypesNotFound = New(CodeGameFishTypesNotFound, "game fish types not found")
+	ErrSkillOnCooldown       = New(CodeSkillOnCooldown, "skill is on cooldown")
+	ErrUnauthorized          = New(CodeUnauthorized, "missing access token")
+	ErrInvalidToken          = New(CodeInvalidToken, "invalid or expired access token")
+	ErrForbidden             = New(CodeForbidden, "insufficient role")
+	ErrInvalidBuyIn          = New(CodeInvalidBuyIn, "buy-in must be >= 0")
+	ErrWalletUnavailable     = New(CodeWalletUnavailable, "wallet provider unavailable")
+	ErrWalletTxNotFound      = New(CodeWalletTxNotFound, "wallet transaction not found")
+	ErrWalletTxRolledBack    = New(CodeWalletTxRolledBack, "wallet transaction was rolled back")
+	ErrInvalidBulletID       = New(CodeInvalidBulletID, "bullet id must be 1-64 characters of letters, digits, '-' or '_'")
+	ErrShotInProgress        = New(CodeShotInProgress, "shot with this bullet id is still being processed")
+	ErrBulletIDConflict      = New(CodeBulletIDConflict, "bullet id was already used for a different shot")
+	ErrBulletNotFound        = New(CodeBulletNotFound, "bullet not found")
+	ErrBulletExpired         = New(CodeBulletExpired, "bullet expired before it hit")
+	ErrTooManyBullets        = New(CodeTooManyBullets, "too many bullets in flight")
+	ErrFairSessionNotFound   = New(CodeFairSessionNotFound, "provably-fair session not found")
+	ErrFairShotNotFound      = New(CodeFairShotNotFound, "provably-fair shot not found")
+	ErrSeedNotRevealed       = New(CodeSeedNotRevealed, "server seed is revealed when the player leaves the room")
+	ErrInvalidClientSeed     = New(CodeInvalidClientSeed, "client seed must be at most 64 characters")
+	ErrInvalidGameConfig     = New(CodeInvalidGameConfig, "invalid game config")
+	ErrUnknownConfigKind     = New(CodeUnknownConfigKind, "unknown game config kind")
+	ErrConfigVersionConflict = New(CodeConfigVersionConflict, "game config was changed by someone else; reload and retry")
+	ErrConfigVersionNotFound = New(CodeConfigVersionNotFound, "game config version not found")
+	ErrInvalidRolloutPercent = New(CodeInvalidRolloutPercent, "rollout percent must be between 0 and 100")
+	ErrShuttingDown          = New(CodeShuttingDown, "server is shutting down; join another instance")
 )